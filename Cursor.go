@@ -96,7 +96,7 @@ func (c *Cursor) IsNext(t TokenType) bool {
 			continue
 		}
 
-		return token.Type == t
+		return token.Type == t || token.SubType == t
 	}
 
 	return false
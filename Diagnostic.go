@@ -0,0 +1,116 @@
+package parser
+
+import "fmt"
+
+type Severity int
+
+const (
+	SeverityError Severity = iota + 1
+	SeverityWarning
+	SeverityInfo
+	SeverityHint
+)
+
+const (
+	CodeInvalidToken     = "invalid-token"
+	CodeUnexpectedToken  = "unexpected-token"
+	CodeUnclosedBracket  = "unclosed-bracket"
+	CodeStrayBracket     = "stray-bracket"
+	CodeDuplicateArrow   = "duplicate-arrow"
+	CodeFamilySurname    = "family-surname"
+	CodeDuplicateName    = "duplicate-name"
+	CodeDuplicateSurname = "duplicate-surname"
+	CodeOrphanChild      = "orphan-child"
+	CodeStrayPunctuation = "stray-punctuation"
+	CodeDisabledAliases  = "disabled-aliases"
+	CodeDisabledUnknown  = "disabled-unknown"
+	CodeDisabledNumbers  = "disabled-numbers"
+	CodeDisabledLabels   = "disabled-labels"
+)
+
+var errCodes = map[ErrType]string{
+	ErrUnexpected:       CodeUnexpectedToken,
+	ErrUnclosedBracket:  CodeUnclosedBracket,
+	ErrStrayBracket:     CodeStrayBracket,
+	ErrDuplicateArrow:   CodeDuplicateArrow,
+	ErrFamilySurname:    CodeFamilySurname,
+	ErrDuplicateName:    CodeDuplicateName,
+	ErrDuplicateSurname: CodeDuplicateSurname,
+	ErrOrphanChild:      CodeOrphanChild,
+	ErrStrayPunctuation: CodeStrayPunctuation,
+	ErrDisabledAliases:  CodeDisabledAliases,
+	ErrDisabledUnknown:  CodeDisabledUnknown,
+	ErrDisabledNumbers:  CodeDisabledNumbers,
+	ErrDisabledLabels:   CodeDisabledLabels,
+}
+
+type Diagnostic struct {
+	Loc
+	Severity Severity
+	Code     string
+	Message  string
+	Note     string
+	Token    *Token
+	Related  []*DiagnosticRelated
+}
+
+type DiagnosticRelated struct {
+	Loc
+	Message string
+}
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	case SeverityHint:
+		return "hint"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+func (e ErrType) Code() string {
+	code, ok := errCodes[e]
+
+	if !ok {
+		return CodeUnexpectedToken
+	}
+
+	return code
+}
+
+func NewTokenDiagnostic(token *Token, severity Severity, code string, message string) *Diagnostic {
+	return &Diagnostic{
+		Loc:      token.Loc(),
+		Severity: severity,
+		Code:     code,
+		Message:  message,
+		Token:    token,
+	}
+}
+
+func collectDiagnostics(tokens []*Token, opts ParseOptions) (list []*Diagnostic) {
+	for _, token := range tokens {
+		code := ""
+
+		if token.Type == TokenInvalid {
+			code = CodeInvalidToken
+		} else if token.ErrType != 0 {
+			code = token.ErrType.Code()
+		}
+
+		if code == "" {
+			continue
+		}
+
+		message := Message(opts.Language, code, map[string]string{"text": token.Text})
+		list = append(list, NewTokenDiagnostic(token, opts.severity(code), code, message))
+	}
+
+	return
+}
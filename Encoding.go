@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+type Encoding int
+
+const (
+	EncodingRune Encoding = iota
+	EncodingUTF16
+	EncodingUTF8
+)
+
+func (e Encoding) Count(text string) int {
+	switch e {
+	case EncodingUTF16:
+		count := 0
+
+		for _, r := range text {
+			count += utf16.RuneLen(r)
+		}
+
+		return count
+
+	case EncodingUTF8:
+		return len(text)
+
+	default:
+		return utf8.RuneCountInString(text)
+	}
+}
+
+func (e Encoding) runeLen(r rune, size int) int {
+	switch e {
+	case EncodingUTF16:
+		return utf16.RuneLen(r)
+
+	case EncodingUTF8:
+		return size
+
+	default:
+		return 1
+	}
+}
+
+func (pos *Position) Convert(line string, from Encoding, to Encoding) Position {
+	result := Position{Line: pos.Line}
+
+	if from == to {
+		result.Char = pos.Char
+		return result
+	}
+
+	line = strings.TrimSuffix(line, "\r")
+	char := 0
+
+	for offset := 0; offset < len(line); {
+		r, size := utf8.DecodeRuneInString(line[offset:])
+		offset += size
+		char += from.runeLen(r, size)
+
+		if char > pos.Char {
+			break
+		}
+
+		result.Char += to.runeLen(r, size)
+	}
+
+	return result
+}
+
+func (loc *Loc) Convert(src string, from Encoding, to Encoding) Loc {
+	return NewLineIndex(src, from).Convert(*loc, to)
+}
@@ -0,0 +1,239 @@
+package parser
+
+import "strings"
+
+type EdgeType int
+
+const (
+	EdgeParent EdgeType = iota
+	EdgeSpouse
+	EdgeRelation
+)
+
+type Edge struct {
+	Type     EdgeType
+	From     *Individual
+	To       *Individual
+	Label    string
+	Relation *Relation
+}
+
+type Graph struct {
+	Model *Model
+	Edges []*Edge
+
+	out map[*Individual][]*Edge
+	in  map[*Individual][]*Edge
+}
+
+func NewGraph(model *Model) *Graph {
+	g := &Graph{
+		Model: model,
+		out:   map[*Individual][]*Edge{},
+		in:    map[*Individual][]*Edge{},
+	}
+
+	for _, family := range model.Families {
+		for _, rel := range family.Relations {
+			g.addRelation(rel)
+		}
+	}
+
+	return g
+}
+
+func (g *Graph) addRelation(rel *Relation) {
+	sources := g.individuals(rel.Sources)
+	targets := g.individuals(rel.Targets)
+	label := ""
+
+	if rel.Label != nil {
+		label = rel.Label.Text
+	}
+
+	for _, group := range g.spouses(rel.Sources) {
+		for i, a := range group {
+			for _, b := range group[i+1:] {
+				g.addEdge(EdgeSpouse, a, b, label, rel)
+			}
+		}
+	}
+
+	if rel.Arrow == nil {
+		return
+	}
+
+	if rel.IsFamilyDef {
+		for _, parent := range sources {
+			for _, child := range targets {
+				g.addEdge(EdgeParent, parent, child, "", rel)
+			}
+		}
+
+		return
+	}
+
+	arrow := rel.Arrow.Text
+	reverse := strings.HasPrefix(arrow, "<") && !strings.HasSuffix(arrow, ">")
+
+	for _, a := range sources {
+		for _, b := range targets {
+			if reverse {
+				g.addEdge(EdgeRelation, b, a, label, rel)
+			} else {
+				g.addEdge(EdgeRelation, a, b, label, rel)
+			}
+		}
+	}
+}
+
+func (g *Graph) individuals(list *RelList) (result []*Individual) {
+	if list == nil {
+		return
+	}
+
+	for _, person := range list.Persons {
+		ind := g.Model.Individual(person)
+
+		if ind == nil || containsIndividual(result, ind) {
+			continue
+		}
+
+		result = append(result, ind)
+	}
+
+	return
+}
+
+func (g *Graph) spouses(list *RelList) (groups [][]*Individual) {
+	if list == nil {
+		return
+	}
+
+	var group []*Individual
+
+	for i, person := range list.Persons {
+		if i > 0 && !isJoinedByPlus(list.Separators, list.Persons[i-1], person) {
+			groups = append(groups, group)
+			group = nil
+		}
+
+		ind := g.Model.Individual(person)
+
+		if ind != nil && !containsIndividual(group, ind) {
+			group = append(group, ind)
+		}
+	}
+
+	return append(groups, group)
+}
+
+func isJoinedByPlus(separators []*Token, a *Person, b *Person) bool {
+	for _, token := range separators {
+		pos := toPos(token)
+
+		if token.SubType == TokenPlus && pos.Compare(a.End) != PosLt && pos.Compare(b.Start) == PosLt {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *Graph) addEdge(t EdgeType, from *Individual, to *Individual, label string, rel *Relation) {
+	if from == to {
+		return
+	}
+
+	if t != EdgeRelation {
+		for _, edge := range g.out[from] {
+			if edge.Type == t && edge.To == to {
+				return
+			}
+		}
+
+		if t == EdgeSpouse {
+			for _, edge := range g.out[to] {
+				if edge.Type == t && edge.To == from {
+					return
+				}
+			}
+		}
+	}
+
+	edge := &Edge{
+		Type:     t,
+		From:     from,
+		To:       to,
+		Label:    label,
+		Relation: rel,
+	}
+
+	g.Edges = append(g.Edges, edge)
+	g.out[from] = append(g.out[from], edge)
+	g.in[to] = append(g.in[to], edge)
+}
+
+func (g *Graph) EdgesOf(ind *Individual) []*Edge {
+	return append(append([]*Edge{}, g.out[ind]...), g.in[ind]...)
+}
+
+func (g *Graph) Parents(ind *Individual) (list []*Individual) {
+	for _, edge := range g.in[ind] {
+		if edge.Type == EdgeParent {
+			list = append(list, edge.From)
+		}
+	}
+
+	return
+}
+
+func (g *Graph) Children(ind *Individual) (list []*Individual) {
+	for _, edge := range g.out[ind] {
+		if edge.Type == EdgeParent {
+			list = append(list, edge.To)
+		}
+	}
+
+	return
+}
+
+func (g *Graph) Spouses(ind *Individual) (list []*Individual) {
+	for _, edge := range g.out[ind] {
+		if edge.Type == EdgeSpouse {
+			list = append(list, edge.To)
+		}
+	}
+
+	for _, edge := range g.in[ind] {
+		if edge.Type == EdgeSpouse && !containsIndividual(list, edge.From) {
+			list = append(list, edge.From)
+		}
+	}
+
+	return
+}
+
+func (g *Graph) Siblings(ind *Individual) (list []*Individual) {
+	for _, parent := range g.Parents(ind) {
+		for _, child := range g.Children(parent) {
+			if child == ind || containsIndividual(list, child) {
+				continue
+			}
+
+			list = append(list, child)
+		}
+	}
+
+	return
+}
+
+func containsIndividual(list []*Individual, ind *Individual) bool {
+	for _, item := range list {
+		if item == ind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+type LineIndex struct {
+	src      string
+	encoding Encoding
+	starts   []int
+}
+
+func NewLineIndex(src string, encoding Encoding) *LineIndex {
+	starts := []int{0}
+
+	for offset := 0; ; {
+		index := strings.IndexByte(src[offset:], '\n')
+
+		if index < 0 {
+			break
+		}
+
+		offset += index + 1
+		starts = append(starts, offset)
+	}
+
+	return &LineIndex{
+		src:      src,
+		encoding: encoding,
+		starts:   starts,
+	}
+}
+
+func (idx *LineIndex) LineCount() int {
+	return len(idx.starts)
+}
+
+func (idx *LineIndex) LineStart(line int) int {
+	if line < 0 {
+		return 0
+	}
+
+	if line >= len(idx.starts) {
+		return len(idx.src)
+	}
+
+	return idx.starts[line]
+}
+
+func (idx *LineIndex) LineEnd(line int) int {
+	if line < 0 {
+		return 0
+	}
+
+	if line+1 >= len(idx.starts) {
+		return len(idx.src)
+	}
+
+	end := idx.starts[line+1] - 1
+
+	if end > idx.starts[line] && idx.src[end-1] == '\r' {
+		end--
+	}
+
+	return end
+}
+
+func (idx *LineIndex) LineText(line int) string {
+	return idx.src[idx.LineStart(line):idx.LineEnd(line)]
+}
+
+func (idx *LineIndex) Line(offset int) int {
+	offset = min(max(offset, 0), len(idx.src))
+	line, ok := slices.BinarySearch(idx.starts, offset)
+
+	if !ok {
+		line--
+	}
+
+	return line
+}
+
+func (idx *LineIndex) Position(offset int) Position {
+	offset = min(max(offset, 0), len(idx.src))
+	line := idx.Line(offset)
+	start := idx.starts[line]
+
+	for offset > start && offset < len(idx.src) && !utf8.RuneStart(idx.src[offset]) {
+		offset--
+	}
+
+	return Position{
+		Line: line,
+		Char: idx.encoding.Count(idx.src[start:offset]),
+	}
+}
+
+func (idx *LineIndex) Offset(pos Position) int {
+	if pos.Line < 0 {
+		return 0
+	}
+
+	if pos.Line >= len(idx.starts) {
+		return len(idx.src)
+	}
+
+	start := idx.starts[pos.Line]
+	offset := start + pos.Convert(idx.LineText(pos.Line), idx.encoding, EncodingUTF8).Char
+
+	return min(offset, idx.LineEnd(pos.Line))
+}
+
+func (idx *LineIndex) Text(loc Loc) string {
+	start := idx.Offset(loc.Start)
+	end := max(idx.Offset(loc.End), start)
+
+	return idx.src[start:end]
+}
+
+func (idx *LineIndex) Convert(loc Loc, to Encoding) Loc {
+	return Loc{
+		Start: loc.Start.Convert(idx.LineText(loc.Start.Line), idx.encoding, to),
+		End:   loc.End.Convert(idx.LineText(loc.End.Line), idx.encoding, to),
+	}
+}
@@ -0,0 +1,100 @@
+package parser
+
+import "slices"
+
+type Individual struct {
+	ID         int
+	Name       string
+	Aliases    []string
+	Surname    string
+	Family     *Family
+	Definition *Person
+	Persons    []*Person
+	Relation   *Relation
+	IsUnknown  bool
+
+	Sex           Sex
+	SexConfidence Confidence
+}
+
+type Ambiguity struct {
+	Person     *Person
+	Candidates []*Individual
+	IsCycle    bool
+}
+
+type Sex int
+
+const (
+	SexUnknown Sex = iota
+	SexMale
+	SexFemale
+)
+
+type Model struct {
+	Families    []*Family
+	Individuals []*Individual
+	Ambiguities []*Ambiguity
+
+	persons  map[*Person]*Individual
+	families map[string][]*Family
+	scopes   map[scopeKey]*scope
+}
+
+func (m *Model) Individual(person *Person) *Individual {
+	return m.persons[person]
+}
+
+func (m *Model) FamiliesByName(name string) []*Family {
+	return m.families[name]
+}
+
+func (m *Model) FamilyByName(name string) *Family {
+	list := m.families[name]
+
+	if len(list) == 0 {
+		return nil
+	}
+
+	return list[0]
+}
+
+func (m *Model) Lookup(name string, surname string) (list []*Individual) {
+	if surname == "" {
+		for _, ind := range m.Individuals {
+			if ind.HasName(name) {
+				list = append(list, ind)
+			}
+		}
+
+		return
+	}
+
+	if s := m.scopes[m.surnameKey(surname)]; s != nil {
+		list = append(list, s.names[name]...)
+	}
+
+	return
+}
+
+func (m *Model) Unknowns() (list []*Individual) {
+	for _, ind := range m.Individuals {
+		if ind.IsUnknown {
+			list = append(list, ind)
+		}
+	}
+
+	return
+}
+
+func (ind *Individual) HasName(name string) bool {
+	return ind.Name == name || slices.Contains(ind.Aliases, name)
+}
+
+func (ind *Individual) FullName() string {
+	if ind.Surname == "" {
+		return ind.Name
+	}
+
+	return ind.Name + " " + ind.Surname
+}
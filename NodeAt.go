@@ -0,0 +1,96 @@
+package parser
+
+import "slices"
+
+type NodePath struct {
+	Family   *Family
+	Relation *Relation
+	RelList  *RelList
+	Person   *Person
+	Token    *Token
+}
+
+type locNode interface {
+	OverlapType(other Loc) OverlapType
+}
+
+func (root *Root) NodeAt(pos Position) (path NodePath) {
+	loc := Loc{
+		Start: pos,
+		End:   Position{Line: pos.Line, Char: pos.Char + 1},
+	}
+
+	family, ok := findNode(root.Families, loc)
+
+	if !ok {
+		path.Token = findToken(pos, root.Comments)
+		return
+	}
+
+	path.Family = family
+	rel, ok := findNode(family.Relations, loc)
+
+	if !ok {
+		path.Token = findToken(pos, family.Comments, family.Aliases, []*Token{family.Name})
+		return
+	}
+
+	path.Relation = rel
+
+	for _, list := range []*RelList{rel.Sources, rel.Targets} {
+		if list != nil && list.Overlaps(loc) {
+			path.RelList = list
+			break
+		}
+	}
+
+	if path.RelList == nil {
+		path.Token = findToken(pos, rel.Comments, []*Token{rel.Arrow, rel.Label})
+		return
+	}
+
+	person, ok := findNode(path.RelList.Persons, loc)
+
+	if !ok {
+		path.Token = findToken(pos, path.RelList.Separators)
+		return
+	}
+
+	path.Person = person
+	path.Token = findToken(pos, person.Comments, person.Aliases, []*Token{person.Unknown, person.Num, person.Name, person.Surname})
+
+	return
+}
+
+func findNode[T locNode](list []T, loc Loc) (node T, ok bool) {
+	index, ok := slices.BinarySearchFunc(list, loc, func(item T, loc Loc) int {
+		switch item.OverlapType(loc) {
+		case OverlapBefore:
+			return -1
+
+		case OverlapAfter:
+			return 1
+
+		default:
+			return 0
+		}
+	})
+
+	if ok {
+		node = list[index]
+	}
+
+	return
+}
+
+func findToken(pos Position, lists ...[]*Token) *Token {
+	for _, list := range lists {
+		for _, token := range list {
+			if token != nil && token.IsOnPosition(pos.Line, pos.Char) {
+				return token
+			}
+		}
+	}
+
+	return nil
+}
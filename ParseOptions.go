@@ -0,0 +1,112 @@
+package parser
+
+type ParseMode int
+
+const (
+	ModeDefault ParseMode = iota
+	ModeStrict
+	ModeLenient
+)
+
+type Extension int
+
+const (
+	ExtAliases Extension = 1 << iota
+	ExtUnknown
+	ExtNumbers
+	ExtLabels
+)
+
+type ParseOptions struct {
+	Mode               ParseMode
+	SkipComments       bool
+	DisabledExtensions Extension
+	Language           string
+	Encoding           Encoding
+}
+
+func (opts ParseOptions) Enabled(ext Extension) bool {
+	return opts.DisabledExtensions&ext == 0
+}
+
+func (opts ParseOptions) lexer() LexerOptions {
+	return LexerOptions{Encoding: opts.Encoding}
+}
+
+func (opts ParseOptions) severity(code string) Severity {
+	switch opts.Mode {
+	case ModeStrict:
+		return SeverityError
+
+	case ModeLenient:
+		return SeverityWarning
+	}
+
+	switch code {
+	case CodeDisabledAliases, CodeDisabledUnknown, CodeDisabledNumbers, CodeDisabledLabels:
+		return SeverityWarning
+
+	default:
+		return SeverityError
+	}
+}
+
+func skipComments(root *Root) {
+	root.Comments = nil
+
+	for _, family := range root.Families {
+		family.Comments = nil
+
+		for _, rel := range family.Relations {
+			rel.Comments = nil
+
+			for person := range rel.PersonsIter() {
+				person.Comments = nil
+			}
+		}
+	}
+}
+
+func applyExtensions(family *Family, opts ParseOptions) {
+	if opts.DisabledExtensions == 0 {
+		return
+	}
+
+	disable := func(ext Extension, errType ErrType, tokens ...*Token) bool {
+		if opts.Enabled(ext) {
+			return false
+		}
+
+		for _, token := range tokens {
+			if token != nil && token.ErrType == 0 {
+				token.ErrType = errType
+			}
+		}
+
+		return true
+	}
+
+	if disable(ExtAliases, ErrDisabledAliases, family.Aliases...) {
+		family.Aliases = nil
+	}
+
+	for _, rel := range family.Relations {
+		if disable(ExtLabels, ErrDisabledLabels, rel.Label) {
+			rel.Label = nil
+		}
+
+		for person := range rel.PersonsIter() {
+			if disable(ExtAliases, ErrDisabledAliases, person.Aliases...) {
+				person.Aliases = nil
+			}
+
+			if disable(ExtUnknown, ErrDisabledUnknown, person.Unknown) {
+				person.Unknown = nil
+			}
+
+			if disable(ExtNumbers, ErrDisabledNumbers, person.Num) {
+				person.Num = nil
+			}
+		}
+	}
+}
@@ -4,8 +4,9 @@ import "iter"
 
 type Root struct {
 	Loc
-	Families []*Family
-	Comments []*Token
+	Families    []*Family
+	Comments    []*Token
+	Diagnostics []*Diagnostic
 }
 
 type Family struct {
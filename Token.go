@@ -29,7 +29,19 @@ const (
 type ErrType int
 
 const (
-	ErrUnexpected ErrType = 1 << iota
+	ErrUnexpected       ErrType = 1 << iota // 1
+	ErrUnclosedBracket                      // 2
+	ErrStrayBracket                         // 4
+	ErrDuplicateArrow                       // 8
+	ErrFamilySurname                        // 16
+	ErrDuplicateName                        // 32
+	ErrDuplicateSurname                     // 64
+	ErrOrphanChild                          // 128
+	ErrStrayPunctuation                     // 256
+	ErrDisabledAliases                      // 512
+	ErrDisabledUnknown                      // 1024
+	ErrDisabledNumbers                      // 2048
+	ErrDisabledLabels                       // 4096
 )
 
 type Token struct {
@@ -66,7 +78,7 @@ func (token *Token) Loc() Loc {
 }
 
 func (token *Token) IsOnPosition(line, char int) bool {
-	return token.Line == line && token.Char >= char && char < token.EndChar()
+	return token.Line == line && token.Char <= char && char < token.EndChar()
 }
 
 func (token *Token) IsEqual(t *Token) bool {
@@ -0,0 +1,188 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+type Document struct {
+	URI    string
+	Src    string
+	Tokens []*Token
+	Root   *Root
+}
+
+type FamilyRef struct {
+	URI    string
+	Family *Family
+}
+
+type Workspace struct {
+	Options ParseOptions
+
+	mu       sync.RWMutex
+	docs     map[string]*Document
+	families map[string][]FamilyRef
+	model    *Model
+}
+
+func NewWorkspace(opts ParseOptions) *Workspace {
+	return &Workspace{
+		Options:  opts,
+		docs:     map[string]*Document{},
+		families: map[string][]FamilyRef{},
+	}
+}
+
+func (w *Workspace) Update(uri string, src string) *Document {
+	tokens := LexerWithOptions(src, w.Options.lexer())
+
+	doc := &Document{
+		URI:    uri,
+		Src:    src,
+		Tokens: tokens,
+		Root:   ParseTokensWithOptions(tokens, w.Options),
+	}
+
+	w.Set(doc)
+
+	return doc
+}
+
+func (w *Workspace) Set(doc *Document) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.unindex(doc.URI)
+	w.docs[doc.URI] = doc
+	w.index(doc)
+	w.model = nil
+}
+
+func (w *Workspace) Remove(uri string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.docs[uri]; !ok {
+		return
+	}
+
+	w.unindex(uri)
+	delete(w.docs, uri)
+	w.model = nil
+}
+
+func (w *Workspace) Document(uri string) *Document {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.docs[uri]
+}
+
+func (w *Workspace) Documents() []*Document {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.sortedDocuments()
+}
+
+func (w *Workspace) Families(name string) []FamilyRef {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return slices.Clone(w.families[name])
+}
+
+func (w *Workspace) FamilyURI(family *Family) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for _, doc := range w.docs {
+		if slices.Contains(doc.Root.Families, family) {
+			return doc.URI
+		}
+	}
+
+	return ""
+}
+
+func (w *Workspace) Model() *Model {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.model != nil {
+		return w.model
+	}
+
+	var families []*Family
+
+	for _, doc := range w.sortedDocuments() {
+		families = append(families, doc.Root.Families...)
+	}
+
+	w.model = ResolveFamilies(families)
+
+	return w.model
+}
+
+func (w *Workspace) sortedDocuments() []*Document {
+	list := make([]*Document, 0, len(w.docs))
+
+	for _, doc := range w.docs {
+		list = append(list, doc)
+	}
+
+	slices.SortFunc(list, func(a, b *Document) int {
+		return strings.Compare(a.URI, b.URI)
+	})
+
+	return list
+}
+
+func (w *Workspace) index(doc *Document) {
+	for _, family := range doc.Root.Families {
+		for _, name := range familyNames(family) {
+			w.families[name] = append(w.families[name], FamilyRef{
+				URI:    doc.URI,
+				Family: family,
+			})
+		}
+	}
+}
+
+func (w *Workspace) unindex(uri string) {
+	doc, ok := w.docs[uri]
+
+	if !ok {
+		return
+	}
+
+	for _, family := range doc.Root.Families {
+		for _, name := range familyNames(family) {
+			list := slices.DeleteFunc(w.families[name], func(ref FamilyRef) bool {
+				return ref.URI == uri
+			})
+
+			if len(list) == 0 {
+				delete(w.families, name)
+			} else {
+				w.families[name] = list
+			}
+		}
+	}
+}
+
+func familyNames(family *Family) (names []string) {
+	if family.Name != nil {
+		names = append(names, family.Name.Text)
+	}
+
+	for _, alias := range family.Aliases {
+		if !slices.Contains(names, alias.Text) {
+			names = append(names, alias.Text)
+		}
+	}
+
+	return
+}
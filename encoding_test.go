@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLexerEncoding(t *testing.T) {
+	g := NewWithT(t)
+
+	src := "Оля 😀 + Ivan\nAndre\u0301 + Ivan"
+
+	for encoding, expect := range map[Encoding][]int{
+		EncodingRune:  {4, 1, 8, 6, 9},
+		EncodingUTF16: {4, 2, 9, 6, 9},
+		EncodingUTF8:  {7, 4, 14, 7, 10},
+	} {
+		tokens := LexerWithOptions(src, LexerOptions{Encoding: encoding})
+
+		g.Expect(tokens[2].Type).To(Equal(TokenInvalid))
+		g.Expect(tokens[2].Char).To(Equal(expect[0]), "encoding %d", encoding)
+		g.Expect(tokens[2].CharsNum).To(Equal(expect[1]), "encoding %d", encoding)
+		g.Expect(tokens[6].Text).To(Equal("Ivan"))
+		g.Expect(tokens[6].Char).To(Equal(expect[2]), "encoding %d", encoding)
+		g.Expect(tokens[8].Text).To(Equal("Andre\u0301"))
+		g.Expect(tokens[8].CharsNum).To(Equal(expect[3]), "encoding %d", encoding)
+		g.Expect(tokens[12].Char).To(Equal(expect[4]), "encoding %d", encoding)
+	}
+
+	root := ParseWithOptions(src, ParseOptions{Encoding: EncodingUTF16})
+
+	g.Expect(root.Diagnostics[0].Loc).To(Equal(Loc{
+		Start: Position{Line: 0, Char: 4},
+		End:   Position{Line: 0, Char: 6},
+	}))
+}
+
+func TestPositionConvert(t *testing.T) {
+	g := NewWithT(t)
+
+	line := "Оля 😀 + Ivan\r"
+	pos := Position{Line: 3, Char: 8}
+
+	g.Expect(pos.Convert(line, EncodingRune, EncodingUTF16)).To(Equal(Position{Line: 3, Char: 9}))
+	g.Expect(pos.Convert(line, EncodingRune, EncodingUTF8)).To(Equal(Position{Line: 3, Char: 14}))
+
+	pos = Position{Line: 3, Char: 14}
+
+	g.Expect(pos.Convert(line, EncodingUTF8, EncodingRune)).To(Equal(Position{Line: 3, Char: 8}))
+	g.Expect(pos.Convert(line, EncodingUTF8, EncodingUTF16)).To(Equal(Position{Line: 3, Char: 9}))
+
+	pos = Position{Line: 3, Char: 5}
+
+	g.Expect(pos.Convert(line, EncodingUTF16, EncodingRune)).To(Equal(Position{Line: 3, Char: 4}))
+
+	pos = Position{Line: 3, Char: 100}
+
+	g.Expect(pos.Convert(line, EncodingRune, EncodingUTF16)).To(Equal(Position{Line: 3, Char: 13}))
+
+	src := "Family\n\nОля 😀 + Ivan\n"
+	loc := Loc{
+		Start: Position{Line: 2, Char: 4},
+		End:   Position{Line: 2, Char: 5},
+	}
+
+	g.Expect(loc.Convert(src, EncodingRune, EncodingUTF16)).To(Equal(Loc{
+		Start: Position{Line: 2, Char: 4},
+		End:   Position{Line: 2, Char: 6},
+	}))
+	g.Expect(loc.Convert(src, EncodingRune, EncodingUTF8)).To(Equal(Loc{
+		Start: Position{Line: 2, Char: 7},
+		End:   Position{Line: 2, Char: 11},
+	}))
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGraph(t *testing.T) {
+	g := NewWithT(t)
+
+	model := Resolve(Parse(resolverSrc + "\nTaras -> friend Ostap Bondar\n\nMaria <- godmother Oksana\n\nTaras, Stepan - brothers\n"))
+	graph := NewGraph(model)
+
+	find := func(fullName string) *Individual {
+		for _, ind := range model.Individuals {
+			if ind.FullName() == fullName {
+				return ind
+			}
+		}
+
+		t.Fatalf("individual %s not found", fullName)
+
+		return nil
+	}
+
+	names := func(list []*Individual) (result []string) {
+		for _, ind := range list {
+			result = append(result, ind.FullName())
+		}
+
+		return
+	}
+
+	ivan := find("Ivan Petrenko")
+	maria := find("Maria Shevchenko")
+	petro := find("Petro Petrenko")
+	taras := find("Taras Shevchenko")
+
+	g.Expect(names(graph.Children(ivan))).To(Equal([]string{"Petro Petrenko", "Olena Petrenko"}))
+	g.Expect(names(graph.Parents(petro))).To(Equal([]string{"Ivan Petrenko", "Maria Shevchenko"}))
+	g.Expect(names(graph.Siblings(petro))).To(Equal([]string{"Olena Petrenko"}))
+	g.Expect(names(graph.Spouses(petro))).To(Equal([]string{"Anna Petrenko", "Ostap Bondar"}))
+	g.Expect(names(graph.Spouses(ivan))).To(Equal([]string{"Maria Shevchenko"}))
+	g.Expect(names(graph.Parents(maria))).To(Equal([]string{"Taras Shevchenko", "Oksana Shevchenko"}))
+
+	family := model.Families[len(model.Families)-1]
+	stepan := model.Individual(family.Relations[len(family.Relations)-1].Sources.Persons[1])
+
+	g.Expect(stepan.Name).To(Equal("Stepan"))
+	g.Expect(graph.Spouses(stepan)).To(BeEmpty())
+	g.Expect(graph.Spouses(taras)).NotTo(ContainElement(stepan))
+
+	var relations []*Edge
+
+	for _, edge := range graph.Edges {
+		if edge.Type == EdgeRelation {
+			relations = append(relations, edge)
+		}
+	}
+
+	g.Expect(relations).To(HaveLen(2))
+	g.Expect(relations[0].From).To(BeIdenticalTo(taras))
+	g.Expect(relations[0].To.FullName()).To(Equal("Ostap Bondar"))
+	g.Expect(relations[0].Label).To(Equal("friend"))
+	g.Expect(relations[1].From.FullName()).To(Equal("Oksana Shevchenko"))
+	g.Expect(relations[1].To).To(BeIdenticalTo(maria))
+	g.Expect(relations[1].Label).To(Equal("godmother"))
+}
@@ -0,0 +1,401 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type KinshipType int
+
+const (
+	KinshipBlood KinshipType = iota
+	KinshipSpouse
+	KinshipSpouseOfRelative
+	KinshipRelativeOfSpouse
+)
+
+type Kinship struct {
+	Type     KinshipType
+	From     *Individual
+	To       *Individual
+	Up       int
+	Down     int
+	Ancestor *Individual
+	Via      *Individual
+}
+
+type KinshipNamer func(k *Kinship) string
+
+var kinshipNamers = map[string]KinshipNamer{
+	LangEnglish:   englishKinship,
+	LangUkrainian: ukrainianKinship,
+}
+
+var kinshipNamersMu sync.RWMutex
+
+func RegisterKinshipNamer(lang string, namer KinshipNamer) {
+	kinshipNamersMu.Lock()
+	defer kinshipNamersMu.Unlock()
+
+	kinshipNamers[normalizeLang(lang)] = namer
+}
+
+func (g *Graph) Kinship(from *Individual, to *Individual) *Kinship {
+	if k := g.bloodKinship(from, to); k != nil {
+		return k
+	}
+
+	if containsIndividual(g.Spouses(from), to) {
+		return &Kinship{
+			Type: KinshipSpouse,
+			From: from,
+			To:   to,
+		}
+	}
+
+	var best *Kinship
+
+	consider := func(k *Kinship, t KinshipType, via *Individual) {
+		if k == nil || (best != nil && best.Up+best.Down <= k.Up+k.Down) {
+			return
+		}
+
+		k.Type = t
+		k.From = from
+		k.To = to
+		k.Via = via
+		best = k
+	}
+
+	for _, spouse := range g.Spouses(to) {
+		if spouse != from {
+			consider(g.bloodKinship(from, spouse), KinshipSpouseOfRelative, spouse)
+		}
+	}
+
+	for _, spouse := range g.Spouses(from) {
+		if spouse != to {
+			consider(g.bloodKinship(spouse, to), KinshipRelativeOfSpouse, spouse)
+		}
+	}
+
+	return best
+}
+
+func (g *Graph) bloodKinship(from *Individual, to *Individual) *Kinship {
+	fromGenerations := map[*Individual]int{from: 0}
+
+	for ind, generation := range g.Ancestors(from, TraverseOptions{}) {
+		fromGenerations[ind] = generation
+	}
+
+	var best *Kinship
+
+	check := func(ind *Individual, down int) {
+		up, ok := fromGenerations[ind]
+
+		if !ok {
+			return
+		}
+
+		if best != nil && (up+down > best.Up+best.Down || (up+down == best.Up+best.Down && up >= best.Up)) {
+			return
+		}
+
+		best = &Kinship{
+			Type:     KinshipBlood,
+			From:     from,
+			To:       to,
+			Up:       up,
+			Down:     down,
+			Ancestor: ind,
+		}
+	}
+
+	check(to, 0)
+
+	for ind, generation := range g.Ancestors(to, TraverseOptions{}) {
+		check(ind, generation)
+	}
+
+	return best
+}
+
+func (k *Kinship) Name(lang string) string {
+	kinshipNamersMu.RLock()
+	defer kinshipNamersMu.RUnlock()
+
+	lang = normalizeLang(lang)
+	base, _, _ := strings.Cut(lang, "-")
+
+	for _, name := range []string{lang, base, LangEnglish} {
+		if namer, ok := kinshipNamers[name]; ok {
+			return namer(k)
+		}
+	}
+
+	return ""
+}
+
+func sexOf(ind *Individual) Sex {
+	if ind == nil {
+		return SexUnknown
+	}
+
+	return ind.Sex
+}
+
+func englishKinship(k *Kinship) string {
+	sex := sexOf(k.To)
+
+	switch k.Type {
+	case KinshipSpouse:
+		return englishTerm(sex, "", "husband", "wife", "spouse")
+
+	case KinshipSpouseOfRelative:
+		switch {
+		case k.Up == 0:
+			return englishBlood(k.Up, k.Down, sex) + "-in-law"
+
+		case k.Up == 1 && k.Down == 1:
+			return englishTerm(sex, "", "brother-in-law", "sister-in-law", "sibling-in-law")
+
+		case k.Up == 1 && k.Down == 0:
+			return englishTerm(sex, "step", "father", "mother", "parent")
+		}
+
+	case KinshipRelativeOfSpouse:
+		switch {
+		case k.Down == 0:
+			return englishBlood(k.Up, k.Down, sex) + "-in-law"
+
+		case k.Up == 1 && k.Down == 1:
+			return englishTerm(sex, "", "brother-in-law", "sister-in-law", "sibling-in-law")
+
+		case k.Up == 0:
+			return "step" + englishBlood(k.Up, k.Down, sex)
+		}
+
+	default:
+		return englishBlood(k.Up, k.Down, sex)
+	}
+
+	return englishBlood(k.Up, k.Down, sex) + " by marriage"
+}
+
+func englishBlood(up int, down int, sex Sex) string {
+	diff := up - down
+	low := min(up, down)
+	abs := max(diff, -diff)
+	grand := ""
+
+	if abs >= 2 {
+		grand = strings.Repeat("great-", abs-2) + "grand"
+	}
+
+	switch {
+	case abs == 0 && low == 0:
+		return "self"
+
+	case low == 0 && diff > 0:
+		return englishTerm(sex, grand, "father", "mother", "parent")
+
+	case low == 0:
+		return englishTerm(sex, grand, "son", "daughter", "child")
+
+	case low == 1 && diff == 0:
+		return englishTerm(sex, "", "brother", "sister", "sibling")
+
+	case low == 1 && diff > 0:
+		return englishTerm(sex, grand, "uncle", "aunt", "")
+
+	case low == 1:
+		return englishTerm(sex, grand, "nephew", "niece", "")
+	}
+
+	name := englishOrdinal(low-1) + " cousin"
+
+	switch abs {
+	case 0:
+		return name
+
+	case 1:
+		return name + " once removed"
+
+	case 2:
+		return name + " twice removed"
+
+	default:
+		return name + " " + strconv.Itoa(abs) + " times removed"
+	}
+}
+
+func englishTerm(sex Sex, prefix string, male string, female string, neutral string) string {
+	switch {
+	case sex == SexMale:
+		return prefix + male
+
+	case sex == SexFemale:
+		return prefix + female
+
+	case neutral != "":
+		return prefix + neutral
+
+	default:
+		return prefix + male + " or " + prefix + female
+	}
+}
+
+func englishOrdinal(n int) string {
+	words := []string{"", "first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth", "tenth"}
+
+	if n < len(words) {
+		return words[n]
+	}
+
+	suffix := "th"
+
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+
+	return strconv.Itoa(n) + suffix
+}
+
+func ukrainianKinship(k *Kinship) string {
+	sex := sexOf(k.To)
+
+	switch k.Type {
+	case KinshipSpouse:
+		return ukrainianTerm(sex, "чоловік", "дружина")
+
+	case KinshipSpouseOfRelative:
+		switch {
+		case k.Up <= 1 && k.Down == 1:
+			return ukrainianTerm(sex, "зять", "невістка")
+
+		case k.Up == 1 && k.Down == 0:
+			return ukrainianTerm(sex, "вітчим", "мачуха")
+		}
+
+	case KinshipRelativeOfSpouse:
+		switch {
+		case k.Up == 1 && k.Down == 0:
+			return ukrainianByFrom(sexOf(k.From), ukrainianTerm(sex, "тесть", "теща"), ukrainianTerm(sex, "свекор", "свекруха"))
+
+		case k.Up == 1 && k.Down == 1:
+			return ukrainianByFrom(sexOf(k.From), ukrainianTerm(sex, "шурин", "своячка"), ukrainianTerm(sex, "дівер", "зовиця"))
+
+		case k.Up == 0 && k.Down == 1:
+			return ukrainianTerm(sex, "пасинок", "падчерка")
+		}
+
+	default:
+		return ukrainianBlood(k.Up, k.Down, sex)
+	}
+
+	return ukrainianBlood(k.Up, k.Down, sex) + " за шлюбом"
+}
+
+func ukrainianBlood(up int, down int, sex Sex) string {
+	diff := up - down
+	low := min(up, down)
+	abs := max(diff, -diff)
+	index := low
+
+	var male, female string
+
+	switch {
+	case abs == 0 && low == 0:
+		return "та сама особа"
+
+	case abs == 1 && low == 0:
+		if diff > 0 {
+			male, female = "батько", "мати"
+		} else {
+			male, female = "син", "донька"
+		}
+
+	case abs == 0:
+		male, female = "брат", "сестра"
+
+	case abs == 1 && diff > 0:
+		male, female = "дядько", "тітка"
+
+	case abs == 1:
+		male, female = "племінник", "племінниця"
+
+	case diff > 0:
+		index = low + 1
+		pra := strings.Repeat("пра", abs-2)
+		male, female = pra+"дід", pra+"баба"
+
+	case abs == 2:
+		index = low + 1
+		male, female = "онук", "онука"
+
+	default:
+		index = low + 1
+		pra := strings.Repeat("пра", abs-2)
+		male, female = pra+"внук", pra+"внучка"
+	}
+
+	male = ukrainianCousin(index, false) + male
+	female = ukrainianCousin(index, true) + female
+
+	return ukrainianTerm(sex, male, female)
+}
+
+func ukrainianCousin(index int, female bool) string {
+	words := []string{"", "", "двоюрідн", "троюрідн", "чотириюрідн", "п'ятиюрідн", "шестиюрідн", "семиюрідн", "восьмиюрідн", "дев'ятиюрідн", "десятиюрідн"}
+
+	stem := strconv.Itoa(index) + "-юрідн"
+
+	if index < len(words) {
+		stem = words[index]
+	}
+
+	if stem == "" {
+		return ""
+	}
+
+	if female {
+		return stem + "а "
+	}
+
+	return stem + "ий "
+}
+
+func ukrainianTerm(sex Sex, male string, female string) string {
+	switch sex {
+	case SexMale:
+		return male
+
+	case SexFemale:
+		return female
+
+	default:
+		return male + " або " + female
+	}
+}
+
+func ukrainianByFrom(sex Sex, ofMale string, ofFemale string) string {
+	switch sex {
+	case SexMale:
+		return ofMale
+
+	case SexFemale:
+		return ofFemale
+
+	default:
+		return ofMale + " або " + ofFemale
+	}
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestKinship(t *testing.T) {
+	g := NewWithT(t)
+
+	src := `Ivanenko
+
+Grandpa + Grandma =
+Dad
+Uncle
+
+Dad + Mom =
+Me
+Sister
+
+Uncle + Aunt =
+Cousin
+
+Cousin + Kateryna =
+CousinChild
+
+Me + Wife Bondar =
+Son
+
+Sister + Taras =
+Niece
+
+Bondar
+
+WifeDad + WifeMom =
+Wife
+WifeBrother
+`
+
+	model := Resolve(Parse(src))
+	graph := NewGraph(model)
+	individuals := map[string]*Individual{}
+
+	for _, ind := range model.Individuals {
+		individuals[ind.Name] = ind
+		ind.Sex = SexMale
+	}
+
+	for _, name := range []string{"Grandma", "Mom", "Sister", "Aunt", "Kateryna", "Wife", "WifeMom", "Niece"} {
+		individuals[name].Sex = SexFemale
+	}
+
+	individuals["CousinChild"].Sex = SexUnknown
+
+	list := []struct {
+		From      string
+		To        string
+		English   string
+		Ukrainian string
+	}{
+		{"Me", "Me", "self", "та сама особа"},
+		{"Me", "Grandpa", "grandfather", "дід"},
+		{"Me", "Uncle", "uncle", "дядько"},
+		{"Me", "Aunt", "aunt by marriage", "тітка за шлюбом"},
+		{"Me", "Cousin", "first cousin", "двоюрідний брат"},
+		{"Me", "CousinChild", "first cousin once removed", "двоюрідний племінник або двоюрідна племінниця"},
+		{"Me", "Sister", "sister", "сестра"},
+		{"Me", "Taras", "brother-in-law", "зять"},
+		{"Me", "Niece", "niece", "племінниця"},
+		{"Me", "Son", "son", "син"},
+		{"Me", "Wife", "wife", "дружина"},
+		{"Me", "WifeDad", "father-in-law", "тесть"},
+		{"Me", "WifeBrother", "brother-in-law", "шурин"},
+		{"Wife", "Dad", "father-in-law", "свекор"},
+		{"Wife", "Sister", "sister-in-law", "зовиця"},
+		{"Dad", "Wife", "daughter-in-law", "невістка"},
+		{"CousinChild", "Grandpa", "great-grandfather", "прадід"},
+		{"Grandpa", "CousinChild", "great-grandchild", "правнук або правнучка"},
+		{"Niece", "Uncle", "granduncle", "двоюрідний дід"},
+		{"Niece", "Grandma", "great-grandmother", "прабаба"},
+		{"CousinChild", "Me", "first cousin once removed", "двоюрідний дядько"},
+	}
+
+	for _, item := range list {
+		k := graph.Kinship(individuals[item.From], individuals[item.To])
+
+		g.Expect(k).NotTo(BeNil(), "%s and %s", item.From, item.To)
+		g.Expect(k.Name(LangEnglish)).To(Equal(item.English), "%s to %s", item.To, item.From)
+		g.Expect(k.Name("uk-UA")).To(Equal(item.Ukrainian), "%s to %s", item.To, item.From)
+	}
+
+	g.Expect(graph.Kinship(individuals["WifeDad"], individuals["Uncle"])).To(BeNil())
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"regexp"
 	"strings"
 	"sync"
@@ -39,19 +40,19 @@ var rules []Rule = []Rule{
 	},
 	{
 		Type:   TokenUnknown,
-		Regexp: regexp.MustCompile(`^[\p{L}\d"'\-.]*\?`),
+		Regexp: regexp.MustCompile(`^[\p{L}\p{M}\d"'\-.]*\?`),
 	},
 	{
 		Type:   TokenName,
-		Regexp: regexp.MustCompile(`^\p{Lu}[\p{L}\d'.]*(-+[\p{L}\d'.]+)*`),
+		Regexp: regexp.MustCompile(`^\p{Lu}[\p{L}\p{M}\d'.]*(-+[\p{L}\p{M}\d'.]+)*`),
 	},
 	{
 		Type:   TokenWord,
-		Regexp: regexp.MustCompile(`^['"][\p{Ll}\d'".]+(-+[\p{Ll}\d'".]+)*`),
+		Regexp: regexp.MustCompile(`^['"][\p{Ll}\p{M}\d'".]+(-+[\p{Ll}\p{M}\d'".]+)*`),
 	},
 	{
 		Type:   TokenWord,
-		Regexp: regexp.MustCompile(`^\p{Ll}[\p{Ll}\d'".]*(-+[\p{Ll}\d'".]+)*`),
+		Regexp: regexp.MustCompile(`^\p{Ll}[\p{Ll}\p{M}\d'".]*(-+[\p{Ll}\p{M}\d'".]+)*`),
 	},
 	{
 		Type:   TokenArrow,
@@ -92,136 +93,220 @@ var rules []Rule = []Rule{
 	},
 }
 
-func Lexer(src string) (list []*Token) {
-	offset := 0
-	length := len(src)
-	line := 0
-	chars := 0
-	var prev *Token
-	leftOpen := false
-	hasFamilyName := atomic.Bool{}
-	var wg sync.WaitGroup
-
-	for offset < length {
-		var token *Token
-		offsetSrc := src[offset:]
-
-		for _, rule := range rules {
-			match := rule.Regexp.FindStringSubmatch(offsetSrc)
-
-			if match == nil {
-				continue
-			}
+const lexerCheckInterval = 256
 
-			text := match[0]
+type LexerOptions struct {
+	Encoding Encoding
+}
 
-			token = &Token{
-				Type:     rule.Type,
-				SubType:  rule.SubType,
-				Offest:   offset,
-				Length:   len(text),
-				Text:     text,
-				Line:     line,
-				Char:     chars,
-				CharsNum: utf8.RuneCountInString(text),
-			}
+func Lexer(src string) []*Token {
+	return LexerWithOptions(src, LexerOptions{})
+}
 
-			break
-		}
+func LexerWithOptions(src string, opts LexerOptions) []*Token {
+	list, _ := LexerContextWithOptions(context.Background(), src, opts)
 
-		if token == nil {
-			if prev != nil && prev.Type == TokenInvalid {
-				_, size := utf8.DecodeRuneInString(src[prev.Offest:prev.End()])
-				prev.Length += size
-				prev.CharsNum++
-				prev.Text = src[prev.Offest:prev.End()]
-
-				token = prev
-			} else {
-				_, size := utf8.DecodeRuneInString(src[offset:])
-				token = &Token{
-					Type:     TokenInvalid,
-					Offest:   offset,
-					Length:   size,
-					Line:     line,
-					Char:     chars,
-					CharsNum: 1,
-					Text:     src[offset : offset+size],
-				}
-			}
-		}
+	return list
+}
 
-		switch token.Type {
-		case TokenName:
-			if leftOpen {
-				token.SubType = TokenAlias
-			} else {
-				checkSurname(list, token)
+func LexerContext(ctx context.Context, src string) ([]*Token, error) {
+	return LexerContextWithOptions(ctx, src, LexerOptions{})
+}
+
+func LexerContextWithOptions(ctx context.Context, src string, opts LexerOptions) ([]*Token, error) {
+	l := &lexer{
+		src:  src,
+		opts: opts,
+	}
+
+	for step := 1; l.offset < len(src); step++ {
+		if step%lexerCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				l.wg.Wait()
+				return l.list, err
 			}
+		}
 
-		case TokenUnknown:
-			list = mergeUnknown(list, token, src)
+		l.next()
+	}
 
-		case TokenWord:
-			list = mergeWords(list, token, src)
+	l.finish(false)
 
-		case TokenBracket:
-			leftOpen = token.SubType == TokenBracketLeft
+	return l.list, nil
+}
 
-		case TokenNewLine:
-			line++
+type lexer struct {
+	src           string
+	opts          LexerOptions
+	list          []*Token
+	offset        int
+	line          int
+	chars         int
+	prev          *Token
+	leftOpen      bool
+	leftIndex     int
+	hasFamilyName atomic.Bool
+	wg            sync.WaitGroup
+}
 
-		case TokenEmptyLines:
-			line += strings.Count(token.Text, "\n")
+func (l *lexer) next() *Token {
+	var token *Token
+	offsetSrc := l.src[l.offset:]
 
-			wg.Add(1)
-			go func(list []*Token) {
-				defer wg.Done()
+	for _, rule := range rules {
+		match := rule.Regexp.FindStringSubmatch(offsetSrc)
 
-				has := checkFamilyName(list)
+		if match == nil {
+			continue
+		}
 
-				if has {
-					hasFamilyName.Store(true)
-				}
-			}(list)
+		text := match[0]
+
+		token = &Token{
+			Type:     rule.Type,
+			SubType:  rule.SubType,
+			Offest:   l.offset,
+			Length:   len(text),
+			Text:     text,
+			Line:     l.line,
+			Char:     l.chars,
+			CharsNum: l.opts.Encoding.Count(text),
 		}
 
-		if leftOpen {
-			switch token.Type {
-			case TokenName, TokenSurname, TokenBracket, TokenSpace, TokenWord, TokenInvalid:
-				// ok
+		break
+	}
 
-			case TokenPunctuation:
-				if token.SubType != TokenComma {
-					leftOpen = false
-				}
+	if token == nil {
+		_, size := utf8.DecodeRuneInString(offsetSrc)
 
-			default:
-				leftOpen = false
+		if l.prev != nil && l.prev.Type == TokenInvalid {
+			token = l.prev
+			token.Length += size
+			token.CharsNum += l.opts.Encoding.Count(offsetSrc[:size])
+			token.Text = l.src[token.Offest:token.End()]
+		} else {
+			token = &Token{
+				Type:     TokenInvalid,
+				Offest:   l.offset,
+				Length:   size,
+				Line:     l.line,
+				Char:     l.chars,
+				CharsNum: l.opts.Encoding.Count(offsetSrc[:size]),
+				Text:     offsetSrc[:size],
 			}
 		}
+	}
 
-		if token != prev {
-			list = append(list, token)
-			prev = token
-		}
+	if l.leftOpen && !isAliasContinuation(token) {
+		l.leftOpen = false
+		closeAliases(l.list, l.leftIndex)
+	}
 
-		if token.Type == TokenNewLine || token.Type == TokenEmptyLines {
-			chars = 0
+	switch token.Type {
+	case TokenName:
+		if l.leftOpen {
+			token.SubType = TokenAlias
 		} else {
-			chars = prev.EndChar()
+			checkSurname(l.list, token)
+		}
+
+	case TokenUnknown:
+		l.list = mergeUnknown(l.list, token, l.src)
+
+	case TokenWord:
+		l.list = mergeWords(l.list, token, l.src)
+
+	case TokenBracket:
+		if l.leftOpen && token.SubType == TokenBracketLeft {
+			closeAliases(l.list, l.leftIndex)
 		}
 
-		offset = prev.End()
+		l.leftOpen = token.SubType == TokenBracketLeft
+		l.leftIndex = len(l.list)
+
+	case TokenNewLine:
+		l.line++
+
+	case TokenEmptyLines:
+		l.line += strings.Count(token.Text, "\n")
+
+		l.wg.Add(1)
+		go func(list []*Token) {
+			defer l.wg.Done()
+
+			has := checkFamilyName(list)
+
+			if has {
+				l.hasFamilyName.Store(true)
+			}
+		}(l.list)
 	}
 
-	wg.Wait()
+	if token != l.prev {
+		l.list = append(l.list, token)
+		l.prev = token
+	}
 
-	if !hasFamilyName.Load() {
-		checkFamilyName(list)
+	if token.Type == TokenNewLine || token.Type == TokenEmptyLines {
+		l.chars = 0
+	} else {
+		l.chars = token.EndChar()
 	}
 
-	return
+	l.offset = token.End()
+
+	return token
+}
+
+func (l *lexer) finish(hasFamilyName bool) {
+	if l.leftOpen {
+		closeAliases(l.list, l.leftIndex)
+	}
+
+	l.wg.Wait()
+
+	if !hasFamilyName && !l.hasFamilyName.Load() {
+		checkFamilyName(l.list)
+	}
+}
+
+func isAliasContinuation(token *Token) bool {
+	switch token.Type {
+	case TokenName, TokenSurname, TokenBracket, TokenSpace, TokenWord, TokenInvalid:
+		return true
+
+	case TokenPunctuation:
+		return token.SubType == TokenComma
+
+	default:
+		return false
+	}
+}
+
+func closeAliases(list []*Token, start int) {
+	prev := list[start]
+	reverting := false
+
+	for i := start + 1; i < len(list); i++ {
+		token := list[i]
+
+		if token.Type == TokenSpace {
+			continue
+		}
+
+		if token.SubType == TokenAlias {
+			if prev.SubType != TokenBracketLeft && prev.SubType != TokenComma {
+				reverting = true
+			}
+
+			if reverting {
+				token.SubType = 0
+				checkSurname(list[:i], token)
+			}
+		}
+
+		prev = token
+	}
 }
 
 func mergeUnknown(list []*Token, token *Token, src string) []*Token {
@@ -276,20 +361,38 @@ func mergeTokens(list []*Token, prevTokens []*Token, token *Token, src string) [
 	first := prevTokens[0]
 
 	token.Length = token.End() - first.Offest
+	token.CharsNum = token.EndChar() - first.Char
 	token.Offest = first.Offest
 	token.Line = first.Line
 	token.Char = first.Char
 	token.Text = src[token.Offest:token.End()]
-	token.CharsNum = utf8.RuneCountInString(token.Text)
 
 	return list[:len(list)-count]
 }
 
 func checkFamilyName(list []*Token) bool {
+	tokens, ok := familyNameTokens(list)
+
+	if !ok {
+		return false
+	}
+
+	for _, token := range tokens {
+		if token.Type != TokenName {
+			continue
+		}
+
+		token.Type = TokenSurname
+	}
+
+	return true
+}
+
+func familyNameTokens(list []*Token) ([]*Token, bool) {
 	tokens, breakToken := getPrevTokens(list, -1, TokenComment|TokenInvalid|TokenNewLine)
 
 	if breakToken == nil || breakToken.Type == TokenEmptyLines {
-		return false
+		return nil, false
 	}
 
 	total := len(tokens)
@@ -302,24 +405,16 @@ func checkFamilyName(list []*Token) bool {
 	}
 
 	if breakToken != nil && breakToken.Type != TokenEmptyLines {
-		return false
+		return nil, false
 	}
 
 	for _, token := range tokens {
 		if token.Type == TokenPunctuation && token.SubType != TokenComma {
-			return false
+			return nil, false
 		}
 	}
 
-	for _, token := range tokens {
-		if token.Type != TokenName {
-			continue
-		}
-
-		token.Type = TokenSurname
-	}
-
-	return true
+	return tokens, true
 }
 
 func checkSurname(list []*Token, token *Token) {
@@ -583,3 +583,63 @@ func testFile(name string) string {
 
 	return string(str)
 }
+
+func TestUnclosedAlias(t *testing.T) {
+	list := []struct {
+		Src     string
+		Aliases []string
+		Types   map[string]TokenType
+	}{
+		{
+			Src:     "Ivan (Vanya Petrenko + Maria",
+			Aliases: []string{"Vanya"},
+			Types: map[string]TokenType{
+				"Ivan":     TokenName,
+				"Petrenko": TokenSurname,
+				"Maria":    TokenName,
+			},
+		},
+		{
+			Src:     "Ivan (Vanya, Ivanko Petrenko\nMaria",
+			Aliases: []string{"Vanya", "Ivanko"},
+			Types: map[string]TokenType{
+				"Petrenko": TokenSurname,
+				"Maria":    TokenName,
+			},
+		},
+		{
+			Src:     "Ivan (Vanya and Petro = Maria",
+			Aliases: []string{"Vanya"},
+			Types: map[string]TokenType{
+				"Petro": TokenName,
+				"Maria": TokenName,
+			},
+		},
+		{
+			Src:     "Ivan (Vanya Petro) Petrenko",
+			Aliases: []string{"Vanya", "Petro"},
+			Types: map[string]TokenType{
+				"Petrenko": TokenSurname,
+			},
+		},
+	}
+
+	for i, item := range list {
+		var aliases []string
+
+		for _, token := range Lexer(item.Src) {
+			if token.SubType == TokenAlias {
+				aliases = append(aliases, token.Text)
+				continue
+			}
+
+			if expect, ok := item.Types[token.Text]; ok && token.Type != expect {
+				t.Errorf("%d: token %s has type %s, expect %s", i, token.Text, token.Type, expect)
+			}
+		}
+
+		if strings.Join(aliases, ",") != strings.Join(item.Aliases, ",") {
+			t.Errorf("%d: aliases %v, expect %v", i, aliases, item.Aliases)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLineIndex(t *testing.T) {
+	g := NewWithT(t)
+
+	src := "Family\r\n\r\nОля 😀 + Ivan\nPetro"
+	idx := NewLineIndex(src, EncodingRune)
+
+	g.Expect(idx.LineCount()).To(Equal(4))
+	g.Expect(idx.LineStart(2)).To(Equal(10))
+	g.Expect(idx.LineStart(10)).To(Equal(len(src)))
+	g.Expect(idx.LineText(0)).To(Equal("Family"))
+	g.Expect(idx.LineText(1)).To(Equal(""))
+	g.Expect(idx.LineText(3)).To(Equal("Petro"))
+
+	for _, token := range Lexer(src) {
+		loc := token.Loc()
+
+		g.Expect(idx.Position(token.Offest)).To(Equal(loc.Start), "token %q", token.Text)
+		g.Expect(idx.Offset(loc.Start)).To(Equal(token.Offest), "token %q", token.Text)
+
+		if token.Type != TokenEmptyLines && token.Type != TokenNewLine {
+			g.Expect(idx.Text(loc)).To(Equal(token.Text), "token %q", token.Text)
+		}
+	}
+
+	g.Expect(idx.Position(len(src))).To(Equal(Position{Line: 3, Char: 5}))
+	g.Expect(idx.Position(19)).To(Equal(Position{Line: 2, Char: 4}))
+	g.Expect(idx.Offset(Position{Line: 2, Char: 100})).To(Equal(idx.LineEnd(2)))
+	g.Expect(idx.Offset(Position{Line: 0, Char: 7})).To(Equal(idx.LineEnd(0)))
+	g.Expect(NewLineIndex("ab\ncd", EncodingRune).Text(Loc{End: Position{Line: 0, Char: 10}})).To(Equal("ab"))
+
+	idx = NewLineIndex(src, EncodingUTF16)
+	loc := Loc{
+		Start: Position{Line: 2, Char: 4},
+		End:   Position{Line: 2, Char: 13},
+	}
+
+	g.Expect(idx.Text(loc)).To(Equal("😀 + Ivan"))
+	g.Expect(idx.Position(21)).To(Equal(Position{Line: 2, Char: 6}))
+	g.Expect(idx.Convert(loc, EncodingUTF8)).To(Equal(Loc{
+		Start: Position{Line: 2, Char: 7},
+		End:   Position{Line: 2, Char: 18},
+	}))
+}
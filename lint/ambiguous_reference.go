@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	parser "github.com/redexp/familymarkup-parser"
+)
+
+const RuleAmbiguousReference = "ambiguous-reference"
+
+func init() {
+	parser.RegisterCatalog(parser.LangEnglish, parser.Catalog{
+		RuleAmbiguousReference:                 `"{name}" may refer to {count} different persons`,
+		RuleAmbiguousReference + ".cycle":      `"{name}" refers to the person defined on line {line}, who would become their own ancestor`,
+		RuleAmbiguousReference + ".related":    `candidate "{name}" is defined here`,
+		RuleAmbiguousReference + ".note":       `give each person an alias, e.g. "{name} (Alias)", number the children or qualify the reference with a surname`,
+		RuleAmbiguousReference + ".note.alias": `refer to the intended person by alias: {aliases}`,
+	})
+
+	parser.RegisterCatalog(parser.LangUkrainian, parser.Catalog{
+		RuleAmbiguousReference:                 `"{name}" може посилатися на {count} різних осіб`,
+		RuleAmbiguousReference + ".cycle":      `"{name}" посилається на особу, визначену в рядку {line}, яка стала б власним предком`,
+		RuleAmbiguousReference + ".related":    `кандидата "{name}" визначено тут`,
+		RuleAmbiguousReference + ".note":       `дайте кожній особі псевдонім, наприклад "{name} (Псевдонім)", пронумеруйте дітей або уточніть посилання прізвищем`,
+		RuleAmbiguousReference + ".note.alias": `посилайтеся на потрібну особу за псевдонімом: {aliases}`,
+	})
+
+	Register(NewRule(RuleAmbiguousReference, parser.SeverityWarning, checkAmbiguousReference))
+}
+
+func checkAmbiguousReference(ctx *Context) {
+	for _, item := range ctx.Model().Ambiguities {
+		person := item.Person
+		name := person.Name.Text
+
+		args := map[string]string{
+			"name":  name,
+			"count": strconv.Itoa(len(item.Candidates)),
+		}
+
+		message := ctx.Message(args)
+
+		if item.IsCycle {
+			args["line"] = strconv.Itoa(item.Candidates[0].Definition.Start.Line + 1)
+			message = parser.Message(ctx.Language, RuleAmbiguousReference+".cycle", args)
+		}
+
+		d := ctx.ReportToken(person.Name, message)
+
+		var aliases []string
+
+		for _, ind := range item.Candidates {
+			for _, alias := range ind.Aliases {
+				aliases = append(aliases, `"`+alias+`"`)
+			}
+
+			if ind.Definition == nil {
+				continue
+			}
+
+			d.Related = append(d.Related, &parser.DiagnosticRelated{
+				Loc:     ind.Definition.Loc,
+				Message: parser.Message(ctx.Language, RuleAmbiguousReference+".related", map[string]string{"name": definitionName(ind.Definition)}),
+			})
+		}
+
+		if len(aliases) > 0 && !item.IsCycle {
+			args["aliases"] = strings.Join(aliases, ", ")
+			d.Note = parser.Message(ctx.Language, RuleAmbiguousReference+".note.alias", args)
+		} else {
+			d.Note = parser.Message(ctx.Language, RuleAmbiguousReference+".note", args)
+		}
+	}
+}
+
+func definitionName(person *parser.Person) string {
+	name := person.Name.Text
+
+	if person.Num != nil {
+		name = person.Num.Text + " " + name
+	}
+
+	return name
+}
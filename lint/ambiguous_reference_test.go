@@ -0,0 +1,37 @@
+package lint
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	parser "github.com/redexp/familymarkup-parser"
+)
+
+func TestAmbiguousReference(t *testing.T) {
+	g := NewWithT(t)
+
+	root := parser.Parse("Family\n\nA + B =\nIvan (Vanya)\n\nC + D =\n1. Ivan\n\nIvan + E")
+
+	list := Run(root, Config{Rules: map[string]RuleConfig{RuleDuplicateChild: {Disabled: true}}})
+
+	g.Expect(list).To(HaveLen(1))
+	g.Expect(list[0]).To(PointTo(MatchFields(IgnoreExtras, Fields{
+		"Code":     Equal(RuleAmbiguousReference),
+		"Severity": Equal(parser.SeverityWarning),
+		"Message":  Equal(`"Ivan" may refer to 2 different persons`),
+		"Note":     Equal(`refer to the intended person by alias: "Vanya"`),
+	})))
+	g.Expect(list[0].Start.Line).To(Equal(8))
+	g.Expect(list[0].Related).To(HaveLen(2))
+	g.Expect(list[0].Related[0].Start.Line).To(Equal(3))
+	g.Expect(list[0].Related[1].Message).To(Equal(`candidate "1. Ivan" is defined here`))
+
+	root = parser.Parse("Family\n\nIvan + Maria =\nPetro\n\nPetro + Anna =\nIvan")
+
+	list = Run(root, Config{Language: parser.LangUkrainian})
+
+	g.Expect(list).To(HaveExactElements(PointTo(MatchFields(IgnoreExtras, Fields{
+		"Message": Equal(`"Ivan" посилається на особу, визначену в рядку 7, яка стала б власним предком`),
+	}))))
+}
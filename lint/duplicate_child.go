@@ -0,0 +1,106 @@
+package lint
+
+import (
+	"strconv"
+
+	parser "github.com/redexp/familymarkup-parser"
+)
+
+const RuleDuplicateChild = "duplicate-child"
+
+func init() {
+	parser.RegisterCatalog(parser.LangEnglish, parser.Catalog{
+		RuleDuplicateChild:              `"{name}" is defined as a child in {count} relations`,
+		RuleDuplicateChild + ".related": `other definition of "{name}"`,
+	})
+
+	parser.RegisterCatalog(parser.LangUkrainian, parser.Catalog{
+		RuleDuplicateChild:              `"{name}" визначено дитиною у {count} зв'язках`,
+		RuleDuplicateChild + ".related": `інше визначення "{name}"`,
+	})
+
+	Register(NewRule(RuleDuplicateChild, parser.SeverityError, checkDuplicateChild))
+}
+
+func checkDuplicateChild(ctx *Context) {
+	type key struct {
+		Name    string
+		Surname string
+	}
+
+	keys := []key{}
+	children := map[key][]*parser.Person{}
+
+	for _, family := range ctx.Root.Families {
+		for _, rel := range family.Relations {
+			if !rel.IsFamilyDef || rel.Targets == nil {
+				continue
+			}
+
+			for _, person := range rel.Targets.Persons {
+				if person.Name == nil {
+					continue
+				}
+
+				k := key{
+					Name:    person.Name.Text,
+					Surname: personSurname(person),
+				}
+
+				if _, ok := children[k]; !ok {
+					keys = append(keys, k)
+				}
+
+				children[k] = append(children[k], person)
+			}
+		}
+	}
+
+	for _, k := range keys {
+		list := children[k]
+
+		if len(list) < 2 {
+			continue
+		}
+
+		name := k.Name
+
+		if k.Surname != "" {
+			name += " " + k.Surname
+		}
+
+		args := map[string]string{
+			"name":  name,
+			"count": strconv.Itoa(len(list)),
+		}
+
+		related := parser.Message(ctx.Language, RuleDuplicateChild+".related", args)
+
+		for _, person := range list {
+			d := ctx.ReportToken(person.Name, ctx.Message(args))
+
+			for _, other := range list {
+				if other == person {
+					continue
+				}
+
+				d.Related = append(d.Related, &parser.DiagnosticRelated{
+					Loc:     other.Name.Loc(),
+					Message: related,
+				})
+			}
+		}
+	}
+}
+
+func personSurname(person *parser.Person) string {
+	if person.Surname != nil {
+		return person.Surname.Text
+	}
+
+	if person.Relation == nil || person.Relation.Family == nil || person.Relation.Family.Name == nil {
+		return ""
+	}
+
+	return person.Relation.Family.Name.Text
+}
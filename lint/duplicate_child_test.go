@@ -0,0 +1,30 @@
+package lint
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	parser "github.com/redexp/familymarkup-parser"
+)
+
+func TestDuplicateChild(t *testing.T) {
+	g := NewWithT(t)
+
+	root := parser.Parse("Family\n\nA + B =\nC\nD\n\nE + F = C\n\nG + H = C Other\n\nOther\n\nI + J = C")
+
+	list := Run(root, Config{})
+
+	g.Expect(list).To(HaveLen(4))
+	g.Expect(list).To(HaveEach(PointTo(MatchFields(IgnoreExtras, Fields{
+		"Code":  Equal(RuleDuplicateChild),
+		"Token": PointTo(MatchFields(IgnoreExtras, Fields{"Text": Equal("C")})),
+	}))))
+
+	g.Expect(list[0].Message).To(Equal(`"C Family" is defined as a child in 2 relations`))
+	g.Expect(list[0].Related).To(HaveLen(1))
+	g.Expect(list[0].Related[0].Start.Line).To(Equal(6))
+	g.Expect(list[1].Related[0].Start.Line).To(Equal(3))
+	g.Expect(list[2].Start.Line).To(Equal(8))
+	g.Expect(list[3].Start.Line).To(Equal(12))
+}
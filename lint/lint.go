@@ -0,0 +1,179 @@
+package lint
+
+import (
+	"slices"
+	"sync"
+
+	parser "github.com/redexp/familymarkup-parser"
+)
+
+type Rule interface {
+	Name() string
+	Severity() parser.Severity
+	Check(ctx *Context)
+}
+
+type RuleConfig struct {
+	Disabled bool
+	Severity parser.Severity
+}
+
+type Config struct {
+	Rules    map[string]RuleConfig
+	Language string
+}
+
+type Context struct {
+	Root     *parser.Root
+	Language string
+
+	rule        Rule
+	severity    parser.Severity
+	diagnostics []*parser.Diagnostic
+	model       **parser.Model
+}
+
+type Registry struct {
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+type funcRule struct {
+	name     string
+	severity parser.Severity
+	check    func(ctx *Context)
+}
+
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func NewRule(name string, severity parser.Severity, check func(ctx *Context)) Rule {
+	return &funcRule{
+		name:     name,
+		severity: severity,
+		check:    check,
+	}
+}
+
+func Register(rule Rule) {
+	DefaultRegistry.Register(rule)
+}
+
+func Run(root *parser.Root, config Config) []*parser.Diagnostic {
+	return DefaultRegistry.Run(root, config)
+}
+
+func (r *Registry) Register(rule Rule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, item := range r.rules {
+		if item.Name() == rule.Name() {
+			r.rules[i] = rule
+			return
+		}
+	}
+
+	r.rules = append(r.rules, rule)
+}
+
+func (r *Registry) Rule(name string) Rule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, rule := range r.rules {
+		if rule.Name() == name {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) Rules() []Rule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.rules)
+}
+
+func (r *Registry) Run(root *parser.Root, config Config) (list []*parser.Diagnostic) {
+	var model *parser.Model
+
+	for _, rule := range r.Rules() {
+		conf := config.Rules[rule.Name()]
+
+		if conf.Disabled {
+			continue
+		}
+
+		ctx := &Context{
+			Root:     root,
+			Language: config.Language,
+			rule:     rule,
+			severity: rule.Severity(),
+			model:    &model,
+		}
+
+		if conf.Severity != 0 {
+			ctx.severity = conf.Severity
+		}
+
+		rule.Check(ctx)
+
+		list = append(list, ctx.diagnostics...)
+	}
+
+	slices.SortStableFunc(list, func(a, b *parser.Diagnostic) int {
+		return int(a.Start.Compare(b.Start))
+	})
+
+	return
+}
+
+func (ctx *Context) Model() *parser.Model {
+	if *ctx.model == nil {
+		*ctx.model = parser.Resolve(ctx.Root)
+	}
+
+	return *ctx.model
+}
+
+func (ctx *Context) Report(loc parser.Loc, message string) *parser.Diagnostic {
+	d := &parser.Diagnostic{
+		Loc:      loc,
+		Severity: ctx.severity,
+		Code:     ctx.rule.Name(),
+		Message:  message,
+	}
+
+	ctx.diagnostics = append(ctx.diagnostics, d)
+
+	return d
+}
+
+func (ctx *Context) ReportToken(token *parser.Token, message string) *parser.Diagnostic {
+	d := ctx.Report(token.Loc(), message)
+	d.Token = token
+
+	return d
+}
+
+func (ctx *Context) Message(args map[string]string) string {
+	return parser.Message(ctx.Language, ctx.rule.Name(), args)
+}
+
+func (rule *funcRule) Name() string {
+	return rule.name
+}
+
+func (rule *funcRule) Severity() parser.Severity {
+	return rule.severity
+}
+
+func (rule *funcRule) Check(ctx *Context) {
+	rule.check(ctx)
+}
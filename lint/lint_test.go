@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	parser "github.com/redexp/familymarkup-parser"
+)
+
+func TestRegistry(t *testing.T) {
+	g := NewWithT(t)
+
+	registry := NewRegistry()
+
+	registry.Register(NewRule("no-labels", parser.SeverityWarning, func(ctx *Context) {
+		for _, family := range ctx.Root.Families {
+			for _, rel := range family.Relations {
+				if rel.Label != nil {
+					ctx.ReportToken(rel.Label, ctx.Message(map[string]string{"text": rel.Label.Text}))
+				}
+			}
+		}
+	}))
+
+	registry.Register(NewRule("no-comments", parser.SeverityHint, func(ctx *Context) {
+		for _, token := range ctx.Root.Comments {
+			ctx.ReportToken(token, "comment")
+		}
+	}))
+
+	parser.RegisterCatalog(parser.LangEnglish, parser.Catalog{
+		"no-labels": `label "{text}" is not allowed`,
+	})
+
+	root := parser.Parse("# comment\nFamily\n\nA + B = label\nC")
+
+	g.Expect(registry.Run(root, Config{})).To(HaveExactElements(
+		PointTo(MatchFields(IgnoreExtras, Fields{
+			"Code":     Equal("no-comments"),
+			"Severity": Equal(parser.SeverityHint),
+		})),
+		PointTo(MatchFields(IgnoreExtras, Fields{
+			"Code":    Equal("no-labels"),
+			"Message": Equal(`label "label" is not allowed`),
+		})),
+	))
+
+	list := registry.Run(root, Config{
+		Rules: map[string]RuleConfig{
+			"no-comments": {Disabled: true},
+			"no-labels":   {Severity: parser.SeverityError},
+		},
+	})
+
+	g.Expect(list).To(HaveExactElements(
+		PointTo(MatchFields(IgnoreExtras, Fields{
+			"Code":     Equal("no-labels"),
+			"Severity": Equal(parser.SeverityError),
+		})),
+	))
+
+	var models []*parser.Model
+
+	registry.Register(NewRule("model", parser.SeverityInfo, func(ctx *Context) {
+		models = append(models, ctx.Model())
+	}))
+
+	registry.Register(NewRule("model2", parser.SeverityInfo, func(ctx *Context) {
+		models = append(models, ctx.Model())
+	}))
+
+	registry.Run(root, Config{})
+
+	g.Expect(models).To(HaveLen(2))
+	g.Expect(models[0]).NotTo(BeNil())
+	g.Expect(models[1]).To(BeIdenticalTo(models[0]))
+	g.Expect(registry.Rule("no-labels")).NotTo(BeNil())
+	g.Expect(registry.Rules()).To(HaveLen(4))
+}
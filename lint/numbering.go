@@ -0,0 +1,202 @@
+package lint
+
+import (
+	"iter"
+	"strconv"
+	"strings"
+
+	parser "github.com/redexp/familymarkup-parser"
+)
+
+const (
+	RuleNumberingGap       = "numbering-gap"
+	RuleNumberingDuplicate = "numbering-duplicate"
+	RuleNumberingOrder     = "numbering-order"
+	RuleNumberingMixed     = "numbering-mixed"
+)
+
+func init() {
+	parser.RegisterCatalog(parser.LangEnglish, parser.Catalog{
+		RuleNumberingGap:                    `children numbering skips {missing}`,
+		RuleNumberingDuplicate:              `number {num} is already used by "{name}"`,
+		RuleNumberingDuplicate + ".related": `first use of number {num}`,
+		RuleNumberingOrder:                  `number {num} goes after {prev}`,
+		RuleNumberingMixed:                  `"{name}" is not numbered while other children are`,
+	})
+
+	parser.RegisterCatalog(parser.LangUkrainian, parser.Catalog{
+		RuleNumberingGap:                    `у нумерації дітей пропущено {missing}`,
+		RuleNumberingDuplicate:              `номер {num} уже використано для "{name}"`,
+		RuleNumberingDuplicate + ".related": `перше використання номера {num}`,
+		RuleNumberingOrder:                  `номер {num} іде після {prev}`,
+		RuleNumberingMixed:                  `"{name}" не має номера, хоча інших дітей пронумеровано`,
+	})
+
+	Register(NewRule(RuleNumberingGap, parser.SeverityWarning, checkNumberingGap))
+	Register(NewRule(RuleNumberingDuplicate, parser.SeverityError, checkNumberingDuplicate))
+	Register(NewRule(RuleNumberingOrder, parser.SeverityWarning, checkNumberingOrder))
+	Register(NewRule(RuleNumberingMixed, parser.SeverityWarning, checkNumberingMixed))
+}
+
+type numberedChild struct {
+	Person *parser.Person
+	Num    int
+}
+
+func checkNumberingGap(ctx *Context) {
+	for list := range numberedLists(ctx.Root) {
+		used := map[int]*parser.Person{}
+		last := 0
+
+		for _, child := range list {
+			if used[child.Num] == nil {
+				used[child.Num] = child.Person
+			}
+
+			last = max(last, child.Num)
+		}
+
+		for num := 1; num < last; num++ {
+			if used[num] != nil {
+				continue
+			}
+
+			end := num
+
+			for used[end+1] == nil {
+				end++
+			}
+
+			missing := strconv.Itoa(num)
+
+			if end > num {
+				missing += "-" + strconv.Itoa(end)
+			}
+
+			ctx.ReportToken(used[end+1].Num, ctx.Message(map[string]string{"missing": missing}))
+			num = end
+		}
+	}
+}
+
+func checkNumberingDuplicate(ctx *Context) {
+	for list := range numberedLists(ctx.Root) {
+		used := map[int]*parser.Person{}
+
+		for _, child := range list {
+			first, ok := used[child.Num]
+
+			if !ok {
+				used[child.Num] = child.Person
+				continue
+			}
+
+			args := map[string]string{
+				"num":  strconv.Itoa(child.Num),
+				"name": personName(first),
+			}
+
+			d := ctx.ReportToken(child.Person.Num, ctx.Message(args))
+			d.Related = append(d.Related, &parser.DiagnosticRelated{
+				Loc:     first.Num.Loc(),
+				Message: parser.Message(ctx.Language, RuleNumberingDuplicate+".related", args),
+			})
+		}
+	}
+}
+
+func checkNumberingOrder(ctx *Context) {
+	for list := range numberedLists(ctx.Root) {
+		prev := 0
+
+		for _, child := range list {
+			if child.Num < prev {
+				ctx.ReportToken(child.Person.Num, ctx.Message(map[string]string{
+					"num":  strconv.Itoa(child.Num),
+					"prev": strconv.Itoa(prev),
+				}))
+			}
+
+			prev = max(prev, child.Num)
+		}
+	}
+}
+
+func checkNumberingMixed(ctx *Context) {
+	for _, family := range ctx.Root.Families {
+		for _, rel := range family.Relations {
+			persons := childrenOf(rel)
+			numbered := 0
+
+			for _, person := range persons {
+				if person.Num != nil {
+					numbered++
+				}
+			}
+
+			if numbered == 0 || numbered == len(persons) {
+				continue
+			}
+
+			for _, person := range persons {
+				if person.Num != nil {
+					continue
+				}
+
+				ctx.Report(person.Loc, ctx.Message(map[string]string{"name": personName(person)}))
+			}
+		}
+	}
+}
+
+func numberedLists(root *parser.Root) iter.Seq[[]numberedChild] {
+	return func(yield func([]numberedChild) bool) {
+		for _, family := range root.Families {
+			for _, rel := range family.Relations {
+				var list []numberedChild
+
+				for _, person := range childrenOf(rel) {
+					if person.Num == nil {
+						continue
+					}
+
+					num, err := strconv.Atoi(strings.TrimSuffix(person.Num.Text, "."))
+
+					if err != nil {
+						continue
+					}
+
+					list = append(list, numberedChild{
+						Person: person,
+						Num:    num,
+					})
+				}
+
+				if len(list) > 0 && !yield(list) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func childrenOf(rel *parser.Relation) []*parser.Person {
+	if !rel.IsFamilyDef || rel.Targets == nil {
+		return nil
+	}
+
+	return rel.Targets.Persons
+}
+
+func personName(person *parser.Person) string {
+	switch {
+	case person.Name != nil:
+		return person.Name.Text
+
+	case person.Unknown != nil:
+		return person.Unknown.Text
+
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,40 @@
+package lint
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	parser "github.com/redexp/familymarkup-parser"
+)
+
+func TestNumbering(t *testing.T) {
+	g := NewWithT(t)
+
+	root := parser.Parse("Family\n\nA + B =\n1. C\n3. D\n2. E\n2. F\nG\n\nH + I =\n1. J\n4. K")
+
+	list := Run(root, Config{
+		Rules: map[string]RuleConfig{
+			RuleDuplicateChild: {Disabled: true},
+		},
+	})
+
+	diagnostic := func(code string, line int, message string) any {
+		return PointTo(MatchFields(IgnoreExtras, Fields{
+			"Code":    Equal(code),
+			"Loc":     MatchFields(IgnoreExtras, Fields{"Start": MatchFields(IgnoreExtras, Fields{"Line": Equal(line)})}),
+			"Message": Equal(message),
+		}))
+	}
+
+	g.Expect(list).To(HaveExactElements(
+		diagnostic(RuleNumberingOrder, 5, "number 2 goes after 3"),
+		diagnostic(RuleNumberingDuplicate, 6, `number 2 is already used by "E"`),
+		diagnostic(RuleNumberingOrder, 6, "number 2 goes after 3"),
+		diagnostic(RuleNumberingMixed, 7, `"G" is not numbered while other children are`),
+		diagnostic(RuleNumberingGap, 11, "children numbering skips 2-3"),
+	))
+
+	g.Expect(list[1].Related).To(HaveLen(1))
+	g.Expect(list[1].Related[0].Start.Line).To(Equal(5))
+}
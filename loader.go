@@ -0,0 +1,186 @@
+package parser
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+type LoadOptions struct {
+	Workers    int
+	Extensions []string
+	Parse      ParseOptions
+}
+
+type LoadedFile struct {
+	Path     string
+	Document *Document
+	Err      error
+}
+
+type FileDiagnostic struct {
+	Path string
+	*Diagnostic
+}
+
+type LoadResult struct {
+	Files       []*LoadedFile
+	Diagnostics []FileDiagnostic
+}
+
+var DefaultExtensions = []string{".fml", ".family"}
+
+func LoadDir(ctx context.Context, dir string, opts LoadOptions) (*LoadResult, error) {
+	extensions := opts.Extensions
+
+	if len(extensions) == 0 {
+		extensions = DefaultExtensions
+	}
+
+	var paths []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && slices.Contains(extensions, filepath.Ext(path)) {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadFiles(ctx, paths, opts), nil
+}
+
+func LoadFiles(ctx context.Context, paths []string, opts LoadOptions) *LoadResult {
+	paths = slices.Clone(paths)
+	slices.Sort(paths)
+
+	workers := opts.Workers
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	files := make([]*LoadedFile, len(paths))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(workers, len(paths)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				files[i] = loadFile(ctx, paths[i], opts.Parse)
+			}
+		}()
+	}
+
+	for i := range paths {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	result := &LoadResult{
+		Files: files,
+	}
+
+	for _, file := range files {
+		if file.Document == nil {
+			continue
+		}
+
+		for _, d := range file.Document.Root.Diagnostics {
+			result.Diagnostics = append(result.Diagnostics, FileDiagnostic{
+				Path:       file.Path,
+				Diagnostic: d,
+			})
+		}
+	}
+
+	return result
+}
+
+func (result *LoadResult) Errors() (list []*LoadedFile) {
+	for _, file := range result.Files {
+		if file.Err != nil {
+			list = append(list, file)
+		}
+	}
+
+	return
+}
+
+func (w *Workspace) LoadDir(ctx context.Context, dir string, opts LoadOptions) (*LoadResult, error) {
+	opts.Parse = w.Options
+
+	result, err := LoadDir(ctx, dir, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range result.Files {
+		if file.Document != nil {
+			w.Set(file.Document)
+		}
+	}
+
+	return result, nil
+}
+
+func loadFile(ctx context.Context, path string, opts ParseOptions) *LoadedFile {
+	file := &LoadedFile{
+		Path: path,
+	}
+
+	if file.Err = ctx.Err(); file.Err != nil {
+		return file
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		file.Err = err
+		return file
+	}
+
+	src := string(data)
+	tokens, err := LexerContextWithOptions(ctx, src, opts.lexer())
+
+	if err != nil {
+		file.Err = err
+		return file
+	}
+
+	root, err := parseTokens(ctx, tokens, opts)
+
+	if err != nil {
+		file.Err = err
+		return file
+	}
+
+	file.Document = &Document{
+		URI:    path,
+		Src:    src,
+		Tokens: tokens,
+		Root:   root,
+	}
+
+	return file
+}
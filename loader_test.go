@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLoadDir(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"b.fml":        "Shevchenko\n\nTaras + Oksana =\nMaria\n\nPetro Petrenko + Maria",
+		"a.fml":        "Petrenko\n\nIvan + Anna = = Petro",
+		"sub/c.family": "Bondar\n\nOstap !",
+		"notes.txt":    "not a family file",
+	}
+
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+
+		g.Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		g.Expect(os.WriteFile(path, []byte(src), 0o644)).To(Succeed())
+	}
+
+	result, err := LoadDir(context.Background(), dir, LoadOptions{Workers: 2})
+
+	g.Expect(err).To(BeNil())
+	g.Expect(result.Errors()).To(BeEmpty())
+
+	var paths []string
+
+	for _, file := range result.Files {
+		paths = append(paths, file.Path)
+	}
+
+	g.Expect(paths).To(Equal([]string{
+		filepath.Join(dir, "a.fml"),
+		filepath.Join(dir, "b.fml"),
+		filepath.Join(dir, "sub/c.family"),
+	}))
+
+	g.Expect(result.Diagnostics).To(HaveLen(2))
+	g.Expect(result.Diagnostics[0].Path).To(Equal(paths[0]))
+	g.Expect(result.Diagnostics[0].Code).To(Equal(CodeDuplicateArrow))
+	g.Expect(result.Diagnostics[1].Path).To(Equal(paths[2]))
+	g.Expect(result.Diagnostics[1].Code).To(Equal(CodeInvalidToken))
+
+	result = LoadFiles(context.Background(), []string{filepath.Join(dir, "missing.fml"), paths[0]}, LoadOptions{})
+
+	g.Expect(result.Files[0].Document).NotTo(BeNil())
+	g.Expect(result.Errors()).To(HaveLen(1))
+	g.Expect(result.Errors()[0].Err).To(MatchError(os.ErrNotExist))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result = LoadFiles(ctx, paths, LoadOptions{})
+
+	g.Expect(result.Errors()).To(HaveLen(3))
+
+	w := NewWorkspace(ParseOptions{})
+	_, err = w.LoadDir(context.Background(), dir, LoadOptions{})
+
+	g.Expect(err).To(BeNil())
+	g.Expect(w.Documents()).To(HaveLen(3))
+
+	petro := w.Model().Lookup("Petro", "Petrenko")
+
+	g.Expect(petro).To(HaveLen(1))
+	g.Expect(petro[0].Persons).To(HaveLen(2))
+}
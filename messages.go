@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"strings"
+	"sync"
+)
+
+type Catalog map[string]string
+
+const (
+	LangEnglish   = "en"
+	LangUkrainian = "uk"
+)
+
+var catalogs = map[string]Catalog{
+	LangEnglish: {
+		CodeInvalidToken:     `invalid characters "{text}"`,
+		CodeUnexpectedToken:  `unexpected "{text}"`,
+		CodeUnclosedBracket:  `unclosed bracket, expected ")" after aliases`,
+		CodeStrayBracket:     `closing bracket without opening one`,
+		CodeDuplicateArrow:   `relation already has an arrow, unexpected "{text}"`,
+		CodeFamilySurname:    `family header can have only one name, unexpected "{text}"`,
+		CodeDuplicateName:    `person already has a name, unexpected "{text}"`,
+		CodeDuplicateSurname: `person already has a surname, unexpected "{text}"`,
+		CodeOrphanChild:      `numbered child "{text}" is separated from its relation by empty lines`,
+		CodeStrayPunctuation: `unexpected punctuation "{text}"`,
+		CodeDisabledAliases:  `aliases are not enabled, unexpected "{text}"`,
+		CodeDisabledUnknown:  `unknown persons are not enabled, unexpected "{text}"`,
+		CodeDisabledNumbers:  `numbered children are not enabled, unexpected "{text}"`,
+		CodeDisabledLabels:   `relation labels are not enabled, unexpected "{text}"`,
+	},
+	LangUkrainian: {
+		CodeInvalidToken:     `неприпустимі символи "{text}"`,
+		CodeUnexpectedToken:  `неочікуване "{text}"`,
+		CodeUnclosedBracket:  `незакрита дужка, після псевдонімів очікується ")"`,
+		CodeStrayBracket:     `закривна дужка без відкривної`,
+		CodeDuplicateArrow:   `у зв'язку вже є стрілка, неочікуване "{text}"`,
+		CodeFamilySurname:    `заголовок родини може мати лише одну назву, неочікуване "{text}"`,
+		CodeDuplicateName:    `особа вже має ім'я, неочікуване "{text}"`,
+		CodeDuplicateSurname: `особа вже має прізвище, неочікуване "{text}"`,
+		CodeOrphanChild:      `нумеровану дитину "{text}" відокремлено від її зв'язку порожніми рядками`,
+		CodeStrayPunctuation: `неочікуваний розділовий знак "{text}"`,
+		CodeDisabledAliases:  `псевдоніми не ввімкнено, неочікуване "{text}"`,
+		CodeDisabledUnknown:  `невідомих осіб не ввімкнено, неочікуване "{text}"`,
+		CodeDisabledNumbers:  `нумерацію дітей не ввімкнено, неочікуване "{text}"`,
+		CodeDisabledLabels:   `мітки зв'язків не ввімкнено, неочікуване "{text}"`,
+	},
+}
+
+var catalogsMu sync.RWMutex
+
+func RegisterCatalog(lang string, catalog Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	lang = normalizeLang(lang)
+	target, ok := catalogs[lang]
+
+	if !ok {
+		target = Catalog{}
+		catalogs[lang] = target
+	}
+
+	for code, message := range catalog {
+		target[code] = message
+	}
+}
+
+func Message(lang string, code string, args map[string]string) string {
+	template := lookupMessage(lang, code)
+
+	if len(args) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	pairs := make([]string, 0, len(args)*2)
+
+	for name, value := range args {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+func lookupMessage(lang string, code string) string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	lang = normalizeLang(lang)
+	base, _, _ := strings.Cut(lang, "-")
+
+	for _, name := range []string{lang, base, LangEnglish} {
+		if message, ok := catalogs[name][code]; ok {
+			return message
+		}
+	}
+
+	return code
+}
+
+func normalizeLang(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+}
@@ -1,24 +1,93 @@
 package parser
 
+import (
+	"context"
+	"slices"
+)
+
 func Parse(src string) *Root {
 	return ParseTokens(Lexer(src))
 }
 
 func ParseTokens(tokens []*Token) *Root {
-	return visitRoot(NewCursor(tokens))
+	return ParseTokensWithOptions(tokens, ParseOptions{})
+}
+
+func ParseWithOptions(src string, opts ParseOptions) *Root {
+	return ParseTokensWithOptions(LexerWithOptions(src, opts.lexer()), opts)
+}
+
+func ParseTokensWithOptions(tokens []*Token, opts ParseOptions) *Root {
+	root, _ := parseTokens(context.Background(), tokens, opts)
+
+	return root
+}
+
+func ParseContext(ctx context.Context, src string) (*Root, error) {
+	return ParseContextWithOptions(ctx, src, ParseOptions{})
 }
 
-func visitRoot(c *Cursor) *Root {
-	root := &Root{}
+func ParseContextWithOptions(ctx context.Context, src string, opts ParseOptions) (*Root, error) {
+	tokens, err := LexerContextWithOptions(ctx, src, opts.lexer())
+
+	if err != nil {
+		root, _ := parseTokens(context.Background(), completeBlocks(tokens), opts)
+
+		return root, err
+	}
+
+	return parseTokens(ctx, tokens, opts)
+}
+
+func completeBlocks(tokens []*Token) []*Token {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if tokens[i].Type == TokenEmptyLines {
+			return tokens[:i+1]
+		}
+	}
+
+	return nil
+}
+
+func parseTokens(ctx context.Context, tokens []*Token, opts ParseOptions) (*Root, error) {
+	root, err := visitRoot(ctx, NewCursor(tokens), opts)
+
+	if err != nil {
+		return root, err
+	}
+
+	return finishRoot(root, tokens, opts), nil
+}
+
+func finishRoot(root *Root, tokens []*Token, opts ParseOptions) *Root {
+	if opts.SkipComments {
+		skipComments(root)
+	}
+
+	root.Diagnostics = collectDiagnostics(tokens, opts)
+
+	slices.SortStableFunc(root.Diagnostics, func(a, b *Diagnostic) int {
+		return int(a.Start.Compare(b.Start))
+	})
+
+	return root
+}
+
+func visitRoot(ctx context.Context, c *Cursor, opts ParseOptions) (root *Root, err error) {
+	root = &Root{}
 	start := c.PickNext()
 
 	if start == nil {
-		return root
+		return
 	}
 
 	root.Start = toPos(start)
 
 	for token := range c.Iter() {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
 		switch token.Type {
 		case TokenComment:
 			root.Comments = append(root.Comments, token)
@@ -27,18 +96,29 @@ func visitRoot(c *Cursor) *Root {
 			continue
 
 		default:
-			f := visitFamily(c)
+			f := visitFamily(c, opts)
+			applyExtensions(f, opts)
 			root.Families = append(root.Families, f)
 			c.StepBackIfNotEnd()
 		}
 	}
 
+	if err != nil {
+		root.End = root.Start
+
+		if count := len(root.Families); count > 0 {
+			root.End = root.Families[count-1].End
+		}
+
+		return
+	}
+
 	root.End = toEndPos(c.PickPrev())
 
-	return root
+	return
 }
 
-func visitFamily(c *Cursor) (family *Family) {
+func visitFamily(c *Cursor, opts ParseOptions) (family *Family) {
 	family = &Family{}
 	family.Start = toPos(c.PickNext())
 
@@ -60,18 +140,46 @@ func visitFamily(c *Cursor) (family *Family) {
 
 			tokens := c.GetAllNext(TokenSurname | TokenBracket | TokenPunctuation | TokenSpace | TokenInvalid)
 
+			var left *Token
+
 			for _, token := range tokens {
 				if token.SubType == TokenAlias {
 					family.Aliases = append(family.Aliases, token)
 					continue
 				}
 
-				if token.Type == TokenSurname {
-					token.ErrType = ErrUnexpected
-					continue
+				switch token.Type {
+				case TokenSurname:
+					token.ErrType = ErrFamilySurname
+
+					if opts.Mode == ModeLenient {
+						family.Aliases = append(family.Aliases, token)
+					}
+
+				case TokenBracket:
+					if token.SubType == TokenBracketLeft {
+						if left != nil {
+							left.ErrType = ErrUnclosedBracket
+						}
+
+						left = token
+					} else if left == nil {
+						token.ErrType = ErrStrayBracket
+					} else {
+						left = nil
+					}
+
+				case TokenPunctuation:
+					if left == nil || token.SubType != TokenComma {
+						token.ErrType = ErrStrayPunctuation
+					}
 				}
 			}
 
+			if left != nil {
+				left.ErrType = ErrUnclosedBracket
+			}
+
 		case TokenComment:
 			family.Comments = append(family.Comments, token)
 
@@ -79,10 +187,25 @@ func visitFamily(c *Cursor) (family *Family) {
 			continue
 
 		case TokenName, TokenUnknown, TokenNum:
-			rel := visitRelation(c)
+			rel := visitRelation(c, opts)
 			family.Relations = append(family.Relations, rel)
 			c.StepBackIfNotEnd()
 
+		case TokenPunctuation:
+			token.ErrType = ErrStrayPunctuation
+
+		case TokenBracket:
+			token.ErrType = ErrStrayBracket
+
+		case TokenArrow:
+			token.ErrType = ErrUnexpected
+
+			if opts.Mode == ModeLenient {
+				rel := visitRelation(c, opts)
+				family.Relations = append(family.Relations, rel)
+				c.StepBackIfNotEnd()
+			}
+
 		default:
 			token.ErrType = ErrUnexpected
 		}
@@ -93,7 +216,7 @@ func visitFamily(c *Cursor) (family *Family) {
 	return
 }
 
-func visitRelation(c *Cursor) (rel *Relation) {
+func visitRelation(c *Cursor, opts ParseOptions) (rel *Relation) {
 	rel = &Relation{
 		Sources: &RelList{},
 	}
@@ -149,11 +272,15 @@ func visitRelation(c *Cursor) (rel *Relation) {
 			c.StepBackIfNotEnd()
 
 		case TokenWord, TokenPunctuation:
+			if opts.Mode == ModeStrict && token.SubType != TokenPlus && token.SubType != TokenComma {
+				token.ErrType = ErrUnexpected
+			}
+
 			list.Separators = append(list.Separators, token)
 
 		case TokenArrow:
 			if rel.Arrow != nil {
-				token.ErrType = ErrUnexpected
+				token.ErrType = ErrDuplicateArrow
 				continue
 			}
 
@@ -174,7 +301,7 @@ func visitRelation(c *Cursor) (rel *Relation) {
 
 		case TokenEmptyLines:
 			if list == rel.Targets && c.IsNext(TokenNum) {
-				token.ErrType = ErrUnexpected
+				c.PickNext().ErrType = ErrOrphanChild
 				continue
 			}
 			rel.End = toEndPos(c.PickPrev())
@@ -198,6 +325,7 @@ func visitPerson(c *Cursor) (p *Person) {
 	p.Start = toPos(c.PickNext())
 
 	isStartOfLine := c.IsStartOfNewLine()
+	bracketOpen := false
 
 	for token := range c.Iter() {
 		switch token.Type {
@@ -219,7 +347,7 @@ func visitPerson(c *Cursor) (p *Person) {
 				continue
 			}
 
-			token.ErrType = ErrUnexpected
+			token.ErrType = ErrDuplicateName
 
 		case TokenSurname:
 			if p.Surname == nil {
@@ -227,13 +355,19 @@ func visitPerson(c *Cursor) (p *Person) {
 				continue
 			}
 
-			token.ErrType = ErrUnexpected
+			token.ErrType = ErrDuplicateSurname
 
 		case TokenBracket:
 			if token.SubType == TokenBracketRight {
+				if !bracketOpen {
+					token.ErrType = ErrStrayBracket
+				}
+
+				bracketOpen = false
 				continue
 			}
 
+			left := token
 			tokens := c.GetAllNext(TokenAlias | TokenComma | TokenSpace)
 
 			for _, token := range tokens {
@@ -242,6 +376,12 @@ func visitPerson(c *Cursor) (p *Person) {
 				}
 			}
 
+			bracketOpen = c.IsNext(TokenBracketRight)
+
+			if !bracketOpen {
+				left.ErrType = ErrUnclosedBracket
+			}
+
 		case TokenComment:
 			p.Comments = append(p.Comments, token)
 
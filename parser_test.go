@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"testing"
@@ -169,6 +170,299 @@ func TestNameless(t *testing.T) {
 	}))
 }
 
+func TestDiagnostics(t *testing.T) {
+	g := NewWithT(t)
+
+	root := Parse("Family Unexpected (Alias)\n\nName + Name2 = = Child !")
+
+	g.Expect(root.Diagnostics).To(testArr(
+		testPoint(Fields{
+			"Loc":      testLoc(0, 7, 0, 17),
+			"Severity": Equal(SeverityError),
+			"Code":     Equal(CodeFamilySurname),
+			"Token":    testToken("Unexpected"),
+		}),
+		testPoint(Fields{
+			"Loc":      testLoc(2, 15, 2, 16),
+			"Severity": Equal(SeverityError),
+			"Code":     Equal(CodeDuplicateArrow),
+			"Token":    testToken("="),
+		}),
+		testPoint(Fields{
+			"Loc":      testLoc(2, 23, 2, 24),
+			"Severity": Equal(SeverityError),
+			"Code":     Equal(CodeInvalidToken),
+			"Message":  ContainSubstring(`"!"`),
+		}),
+	))
+
+	g.Expect(Parse(testFile("nameless.family")).Diagnostics).To(BeEmpty())
+}
+
+func TestErrTypes(t *testing.T) {
+	list := []struct {
+		Src  string
+		Text string
+		Err  ErrType
+	}{
+		{"Family Other\n\nA + B", "Other", ErrFamilySurname},
+		{"Family (Alias\n\nA + B", "(", ErrUnclosedBracket},
+		{"Family\n\nA + B = = C", "=", ErrDuplicateArrow},
+		{"Family\n\nA B Surname + C", "B", ErrDuplicateName},
+		{"Family\n\nA (Alias + B", "(", ErrUnclosedBracket},
+		{"Family\n\nA) + B", ")", ErrStrayBracket},
+		{"Family\n\nA + B =\n1. C\n\n2. D", "2.", ErrOrphanChild},
+		{"Family\n\n+ A", "+", ErrStrayPunctuation},
+	}
+
+	for i, item := range list {
+		tokens := Lexer(item.Src)
+		ParseTokens(tokens)
+
+		found := false
+
+		for _, token := range tokens {
+			if token.ErrType == 0 {
+				continue
+			}
+
+			if token.Text != item.Text || token.ErrType != item.Err {
+				t.Errorf("%d: unexpected error %s on %q", i, token.ErrType, token.Text)
+				continue
+			}
+
+			found = true
+		}
+
+		if !found {
+			t.Errorf("%d: expect %s on %q", i, item.Err, item.Text)
+		}
+	}
+}
+
+func TestLocalizedDiagnostics(t *testing.T) {
+	g := NewWithT(t)
+
+	src := "Family\n\nA + B = = C"
+
+	root := ParseWithOptions(src, ParseOptions{Language: "uk-UA"})
+
+	g.Expect(root.Diagnostics).To(testArr(testPoint(Fields{
+		"Code":    Equal(CodeDuplicateArrow),
+		"Message": Equal(`у зв'язку вже є стрілка, неочікуване "="`),
+	})))
+
+	RegisterCatalog("pl", Catalog{
+		CodeDuplicateArrow: `relacja ma już strzałkę "{text}"`,
+	})
+
+	root = ParseWithOptions(src, ParseOptions{Language: "pl"})
+
+	g.Expect(root.Diagnostics[0].Message).To(Equal(`relacja ma już strzałkę "="`))
+
+	root = ParseWithOptions("Family\n\nA (Alias + B", ParseOptions{Language: "pl"})
+
+	g.Expect(root.Diagnostics[0].Message).To(Equal(`unclosed bracket, expected ")" after aliases`))
+}
+
+func TestParseOptions(t *testing.T) {
+	g := NewWithT(t)
+
+	src := "Family (Alias)\n\n# comment\nA (B) + C = label !"
+
+	severities := func(root *Root) []Severity {
+		list := make([]Severity, len(root.Diagnostics))
+
+		for i, d := range root.Diagnostics {
+			list[i] = d.Severity
+		}
+
+		return list
+	}
+
+	root := ParseWithOptions(src, ParseOptions{Mode: ModeStrict})
+
+	g.Expect(severities(root)).To(Equal([]Severity{SeverityError}))
+	g.Expect(root.Families[0].Comments).To(testTokens("# comment"))
+
+	root = ParseWithOptions(src, ParseOptions{Mode: ModeLenient})
+
+	g.Expect(severities(root)).To(Equal([]Severity{SeverityWarning}))
+
+	root = ParseWithOptions(src, ParseOptions{
+		SkipComments:       true,
+		DisabledExtensions: ExtAliases | ExtLabels,
+	})
+
+	g.Expect(root.Families[0].Comments).To(BeEmpty())
+	g.Expect(root.Families[0].Aliases).To(BeEmpty())
+	g.Expect(root.Families[0].Relations[0].Sources.Persons[0].Aliases).To(BeEmpty())
+	g.Expect(root.Families[0].Relations[0].Label).To(BeNil())
+	g.Expect(root.Diagnostics).To(testArr(
+		testPoint(Fields{
+			"Code":  Equal(CodeDisabledAliases),
+			"Token": testToken("Alias"),
+		}),
+		testPoint(Fields{
+			"Code":  Equal(CodeDisabledAliases),
+			"Token": testToken("B"),
+		}),
+		testPoint(Fields{
+			"Code":  Equal(CodeDisabledLabels),
+			"Token": testToken("label"),
+		}),
+		testPoint(Fields{
+			"Code": Equal(CodeInvalidToken),
+		}),
+	))
+}
+
+func TestParseExtensions(t *testing.T) {
+	g := NewWithT(t)
+
+	src := "Family\n\nA + B =\n1. C\n2. unknown?"
+
+	root := Parse(src)
+	rel := root.Families[0].Relations[0]
+
+	g.Expect(rel.Targets.Persons[0].Num).To(testToken("1."))
+	g.Expect(rel.Targets.Persons[1].Unknown).To(testToken("unknown?"))
+	g.Expect(root.Diagnostics).To(BeEmpty())
+
+	root = ParseWithOptions(src, ParseOptions{DisabledExtensions: ExtNumbers | ExtUnknown})
+	rel = root.Families[0].Relations[0]
+
+	g.Expect(rel.Targets.Persons[0].Num).To(BeNil())
+	g.Expect(rel.Targets.Persons[0].Name).To(testToken("C"))
+	g.Expect(rel.Targets.Persons[1].Num).To(BeNil())
+	g.Expect(rel.Targets.Persons[1].Unknown).To(BeNil())
+	g.Expect(root.Diagnostics).To(testArr(
+		testPoint(Fields{
+			"Code":     Equal(CodeDisabledNumbers),
+			"Severity": Equal(SeverityWarning),
+			"Token":    testToken("1."),
+		}),
+		testPoint(Fields{
+			"Code":  Equal(CodeDisabledNumbers),
+			"Token": testToken("2."),
+		}),
+		testPoint(Fields{
+			"Code":  Equal(CodeDisabledUnknown),
+			"Token": testToken("unknown?"),
+		}),
+	))
+}
+
+func TestParseLenient(t *testing.T) {
+	g := NewWithT(t)
+
+	src := "Petrenko Petrenki\n\n= Petro\n\nIvan + Maria"
+
+	root := Parse(src)
+	family := root.Families[0]
+
+	g.Expect(family.Aliases).To(BeEmpty())
+	g.Expect(family.Relations).To(HaveLen(2))
+	g.Expect(family.Relations[0].Arrow).To(BeNil())
+	g.Expect(family.Relations[0].Sources.Persons[0].Name).To(testToken("Petro"))
+	g.Expect(root.Diagnostics).To(HaveLen(2))
+	g.Expect(root.Diagnostics[0].Severity).To(Equal(SeverityError))
+
+	root = ParseWithOptions(src, ParseOptions{Mode: ModeLenient})
+	family = root.Families[0]
+
+	g.Expect(family.Aliases).To(testTokens("Petrenki"))
+	g.Expect(family.Relations).To(HaveLen(2))
+	g.Expect(family.Relations[0].Sources.Persons).To(BeEmpty())
+	g.Expect(family.Relations[0].Arrow).To(testToken("="))
+	g.Expect(family.Relations[0].Targets.Persons[0].Name).To(testToken("Petro"))
+	g.Expect(family.Relations[1].Sources.Persons).To(HaveLen(2))
+	g.Expect(root.Diagnostics).To(HaveLen(2))
+	g.Expect(root.Diagnostics[0].Severity).To(Equal(SeverityWarning))
+}
+
+func TestParseStrict(t *testing.T) {
+	g := NewWithT(t)
+
+	src := "Family\n\nIvan and Maria = Petro"
+
+	root := ParseWithOptions(src, ParseOptions{})
+
+	g.Expect(root.Diagnostics).To(BeEmpty())
+	g.Expect(root.Families[0].Relations[0].Sources.Persons).To(HaveLen(2))
+
+	root = ParseWithOptions(src, ParseOptions{Mode: ModeStrict})
+
+	g.Expect(root.Diagnostics).To(HaveExactElements(PointTo(MatchFields(IgnoreExtras, Fields{
+		"Code":     Equal(CodeUnexpectedToken),
+		"Severity": Equal(SeverityError),
+		"Token":    testToken("and"),
+	}))))
+}
+
+func TestParseContext(t *testing.T) {
+	g := NewWithT(t)
+
+	src := testFile("main.fml")
+
+	root, err := ParseContext(context.Background(), src)
+
+	g.Expect(err).To(BeNil())
+	g.Expect(root.Families).To(HaveLen(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	root, err = ParseContext(ctx, src)
+
+	g.Expect(err).To(MatchError(context.Canceled))
+	g.Expect(root.Families).To(BeEmpty())
+
+	big := strings.Repeat(src+"\n\n", 100)
+	full := Parse(big)
+
+	root, err = ParseContext(ctx, big)
+
+	g.Expect(err).To(MatchError(context.Canceled))
+	g.Expect(root.Families).NotTo(BeEmpty())
+	g.Expect(len(root.Families)).To(BeNumerically("<", len(full.Families)))
+
+	for i, family := range root.Families[:len(root.Families)-1] {
+		g.Expect(family.Name.Text).To(Equal(full.Families[i].Name.Text))
+		g.Expect(family.Loc).To(Equal(full.Families[i].Loc))
+		g.Expect(family.Relations).To(HaveLen(len(full.Families[i].Relations)))
+	}
+}
+
+func TestUnclosedBracket(t *testing.T) {
+	g := NewWithT(t)
+
+	root := Parse("Family\n\nIvan (Vanya Petrenko + Maria = Petro")
+
+	g.Expect(root.Diagnostics).To(testArr(testPoint(Fields{
+		"Loc":  testLoc(2, 5, 2, 6),
+		"Code": Equal(CodeUnclosedBracket),
+	})))
+
+	g.Expect(root.Families[0].Relations).To(testArr(testPoint(Fields{
+		"Sources": testPoint(Fields{
+			"Persons": testArr(
+				testPoint(Fields{
+					"Name":    testToken("Ivan"),
+					"Aliases": testTokens("Vanya"),
+					"Surname": testToken("Petrenko"),
+				}),
+				testPoint(Fields{
+					"Name": testToken("Maria"),
+				}),
+			),
+		}),
+		"Targets": testPoint(Fields{
+			"Persons": testPersons("Petro"),
+		}),
+	})))
+}
+
 func testLoc(startLine, startChar, endLine, endChar int) M {
 	return testProps(Fields{
 		"Start": testProps(Fields{
@@ -233,3 +527,79 @@ func testPersons(names ...string) M {
 
 	return testArr(persons...)
 }
+
+func TestNodeAt(t *testing.T) {
+	g := NewWithT(t)
+
+	root := Parse(`// comment
+Petrenko (Petrenki)
+
+Ivan + Maria =
+1. Petro (Petrus) Shevchenko
+
+Olena + Taras -> wife? // note
+`)
+
+	family := root.Families[0]
+	rel := family.Relations[0]
+	petro := rel.Targets.Persons[0]
+
+	g.Expect(root.NodeAt(Position{Line: 0, Char: 3})).To(Equal(NodePath{Token: root.Comments[0]}))
+	g.Expect(root.NodeAt(Position{Line: 1, Char: 0})).To(Equal(NodePath{Family: family, Token: family.Name}))
+	g.Expect(root.NodeAt(Position{Line: 1, Char: 7})).To(Equal(NodePath{Family: family, Token: family.Name}))
+	g.Expect(root.NodeAt(Position{Line: 1, Char: 8})).To(Equal(NodePath{Family: family}))
+	g.Expect(root.NodeAt(Position{Line: 1, Char: 10})).To(Equal(NodePath{Family: family, Token: family.Aliases[0]}))
+
+	g.Expect(root.NodeAt(Position{Line: 3, Char: 0})).To(Equal(NodePath{
+		Family:   family,
+		Relation: rel,
+		RelList:  rel.Sources,
+		Person:   rel.Sources.Persons[0],
+		Token:    rel.Sources.Persons[0].Name,
+	}))
+	g.Expect(root.NodeAt(Position{Line: 3, Char: 5})).To(Equal(NodePath{
+		Family:   family,
+		Relation: rel,
+		RelList:  rel.Sources,
+		Token:    rel.Sources.Separators[0],
+	}))
+	g.Expect(root.NodeAt(Position{Line: 3, Char: 13})).To(Equal(NodePath{
+		Family:   family,
+		Relation: rel,
+		Token:    rel.Arrow,
+	}))
+
+	for char, token := range map[int]*Token{0: petro.Num, 3: petro.Name, 10: petro.Aliases[0], 20: petro.Surname} {
+		g.Expect(root.NodeAt(Position{Line: 4, Char: char})).To(Equal(NodePath{
+			Family:   family,
+			Relation: rel,
+			RelList:  rel.Targets,
+			Person:   petro,
+			Token:    token,
+		}), "char %d", char)
+	}
+
+	rel = family.Relations[1]
+
+	g.Expect(root.NodeAt(Position{Line: 6, Char: 18})).To(Equal(NodePath{
+		Family:   family,
+		Relation: rel,
+		RelList:  rel.Targets,
+		Person:   rel.Targets.Persons[0],
+		Token:    rel.Targets.Persons[0].Unknown,
+	}))
+	g.Expect(root.NodeAt(Position{Line: 6, Char: 25}).Token.Type).To(Equal(TokenComment))
+	g.Expect(root.NodeAt(Position{Line: 9, Char: 0})).To(Equal(NodePath{}))
+}
+
+func TestIsOnPosition(t *testing.T) {
+	g := NewWithT(t)
+
+	token := &Token{Line: 1, Char: 4, CharsNum: 3}
+
+	g.Expect(token.IsOnPosition(1, 3)).To(BeFalse())
+	g.Expect(token.IsOnPosition(1, 4)).To(BeTrue())
+	g.Expect(token.IsOnPosition(1, 6)).To(BeTrue())
+	g.Expect(token.IsOnPosition(1, 7)).To(BeFalse())
+	g.Expect(token.IsOnPosition(2, 5)).To(BeFalse())
+}
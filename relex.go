@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+type LexerChange struct {
+	Start  int
+	OldEnd int
+	NewEnd int
+}
+
+// LexerUpdate relexes src, the text after edit was applied, reusing tokens
+// from before the edit. The tokens after the edit are shifted in place and
+// moved into the result, so the old slice must not be used after the call.
+func LexerUpdate(src string, tokens []*Token, edit Edit) ([]*Token, LexerChange) {
+	return LexerUpdateWithOptions(src, tokens, edit, LexerOptions{})
+}
+
+func LexerUpdateWithOptions(src string, tokens []*Token, edit Edit, opts LexerOptions) ([]*Token, LexerChange) {
+	oldLen := 0
+
+	if len(tokens) > 0 {
+		oldLen = tokens[len(tokens)-1].End()
+	}
+
+	edit.End = min(max(edit.End, 0), oldLen)
+	edit.Start = min(max(edit.Start, 0), edit.End)
+	start := restartIndex(tokens, src, edit.Start)
+
+	l := &lexer{
+		src:  src,
+		opts: opts,
+		list: make([]*Token, start, len(tokens)+8),
+	}
+
+	copy(l.list, tokens[:start])
+
+	if start > 0 {
+		l.prev = tokens[start-1]
+		l.offset = l.prev.End()
+		l.line = l.prev.Line + strings.Count(l.prev.Text, "\n")
+	}
+
+	h := newHeaders(tokens)
+	delta := len(edit.Text) - (edit.End - edit.Start)
+	editEnd := edit.Start + len(edit.Text)
+	index := start
+
+	for l.offset < len(src) {
+		token := l.next()
+
+		if token.Type != TokenEmptyLines || token.Offest < editEnd {
+			continue
+		}
+
+		for index < len(tokens) && tokens[index].Offest < token.Offest-delta {
+			index++
+		}
+
+		if index >= len(tokens) {
+			continue
+		}
+
+		old := tokens[index]
+
+		if old.Type != TokenEmptyLines || old.Offest != token.Offest-delta || old.Text != token.Text {
+			continue
+		}
+
+		l.wg.Wait()
+
+		if l.hasFamilyName.Load() != h.between(start, index+1) && !h.before(start) && !h.after(index+1) {
+			continue
+		}
+
+		lineDelta := token.Line - old.Line
+
+		for _, token := range tokens[index+1:] {
+			token.Offest += delta
+			token.Line += lineDelta
+		}
+
+		change := LexerChange{
+			Start:  start,
+			OldEnd: index + 1,
+			NewEnd: len(l.list),
+		}
+
+		return append(l.list, tokens[index+1:]...), change
+	}
+
+	l.finish(h.before(start))
+
+	return l.list, LexerChange{
+		Start:  start,
+		OldEnd: len(tokens),
+		NewEnd: len(l.list),
+	}
+}
+
+func restartIndex(tokens []*Token, src string, offset int) int {
+	index := sort.Search(len(tokens), func(i int) bool {
+		return tokens[i].End() >= offset
+	})
+
+	for i := index - 1; i >= 0; i-- {
+		token := tokens[i]
+
+		if token.Type == TokenEmptyLines && strings.TrimLeft(src[token.End():offset], "\r\n\t ") != "" {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+type headers struct {
+	tokens []*Token
+	first  int
+	last   int
+}
+
+func newHeaders(tokens []*Token) *headers {
+	return &headers{
+		tokens: tokens,
+		first:  -1,
+		last:   -1,
+	}
+}
+
+func (h *headers) before(index int) bool {
+	if h.first < 0 {
+		h.first = len(h.tokens)
+
+		for i := range h.tokens {
+			if h.isHeader(i) {
+				h.first = i
+				break
+			}
+		}
+	}
+
+	return h.first < index
+}
+
+func (h *headers) after(index int) bool {
+	if h.last < 0 {
+		h.last = len(h.tokens)
+
+		for i := len(h.tokens) - 1; i >= 0; i-- {
+			if h.isHeader(i) {
+				h.last = i
+				break
+			}
+		}
+	}
+
+	return h.last >= index && h.last < len(h.tokens)
+}
+
+func (h *headers) between(from int, to int) bool {
+	for i := from; i < to; i++ {
+		if h.isHeader(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *headers) isHeader(index int) bool {
+	if h.tokens[index].Type != TokenEmptyLines {
+		return false
+	}
+
+	_, ok := familyNameTokens(h.tokens[:index])
+
+	return ok
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLexerUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	src := "Family\n\nIvan + Maria =\nPetro\n\nPetro + Anna\n\nOlena + Taras\n"
+	tokens := Lexer(src)
+	tail := tokens[len(tokens)-6:]
+	offset := strings.Index(src, "Anna")
+
+	newSrc := src[:offset] + "Oksana Bondar" + src[offset+len("Anna"):]
+	list, change := LexerUpdate(newSrc, tokens, Edit{Start: offset, End: offset + len("Anna"), Text: "Oksana Bondar"})
+	expect := Lexer(newSrc)
+
+	g.Expect(list).To(Equal(expect))
+	g.Expect(change).To(Equal(LexerChange{Start: 12, OldEnd: 18, NewEnd: 20}))
+	g.Expect(list[len(list)-6:]).To(Equal(tail))
+	g.Expect(list[len(list)-6]).To(BeIdenticalTo(tail[0]))
+
+	src = "Ivan + Maria\n\nPetro + Anna\n\nOlena\n"
+	tokens = Lexer(src)
+
+	g.Expect(tokens[len(tokens)-2].Type).To(Equal(TokenSurname))
+
+	list, change = LexerUpdate("Family\n\n"+src, tokens, Edit{Start: 0, End: 0, Text: "Family\n\n"})
+
+	g.Expect(list).To(Equal(Lexer("Family\n\n" + src)))
+	g.Expect(list[len(list)-2].Type).To(Equal(TokenName))
+	g.Expect(change.OldEnd).To(Equal(len(tokens)))
+}
+
+func TestLexerUpdateMatchesLexer(t *testing.T) {
+	inserts := []string{"", "x", " ", "\n", "\n\n", "Family\n\n", "(", "Ivan ", "mother?", "// c\n"}
+
+	for _, src := range []string{updateSrc, "Ivan + Maria\n\nPetro + Anna\n\n  Olena\n", "A\t\n \n\nB = C\r\n\r\n"} {
+		for start := 0; start <= len(src); {
+			for _, length := range []int{0, 1, 12} {
+				end := min(start+length, len(src))
+
+				for end < len(src) && !utf8.RuneStart(src[end]) {
+					end++
+				}
+
+				for _, text := range inserts {
+					edit := Edit{Start: start, End: end, Text: text}
+					newSrc := src[:start] + text + src[end:]
+					list, change := LexerUpdate(newSrc, Lexer(src), edit)
+					expect := Lexer(newSrc)
+
+					if !reflect.DeepEqual(list, expect) {
+						t.Fatalf("relex %+v differs from lexer of:\n%s", edit, newSrc)
+					}
+
+					if change.Start > change.NewEnd || change.NewEnd > len(list) {
+						t.Fatalf("unexpected change %+v for %+v", change, edit)
+					}
+				}
+			}
+
+			_, size := utf8.DecodeRuneInString(src[start:])
+			start += max(size, 1)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type RenderOptions struct {
+	Filename string
+	Color    bool
+	Encoding Encoding
+}
+
+const (
+	colorReset  = "\x1b[0m"
+	colorBold   = "\x1b[1m"
+	colorRed    = "\x1b[31m"
+	colorYellow = "\x1b[33m"
+	colorBlue   = "\x1b[34m"
+	colorCyan   = "\x1b[36m"
+)
+
+func RenderDiagnostics(w io.Writer, src string, diagnostics []*Diagnostic, opts RenderOptions) error {
+	lines := NewLineIndex(src, opts.Encoding)
+
+	for i, d := range diagnostics {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+
+		if _, err := io.WriteString(w, renderDiagnostic(lines, d, opts)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderDiagnostic(lines *LineIndex, d *Diagnostic, opts RenderOptions) string {
+	var b strings.Builder
+
+	paint := func(color string, text string) string {
+		if !opts.Color || color == "" {
+			return text
+		}
+
+		return color + text + colorReset
+	}
+
+	color := severityColor(d.Severity)
+	line := d.Start.Line
+	lineNum := strconv.Itoa(line + 1)
+	gutter := strings.Repeat(" ", len(lineNum))
+
+	if opts.Filename != "" {
+		b.WriteString(paint(colorBold, fmt.Sprintf("%s:%d:%d: ", opts.Filename, line+1, d.Start.Char+1)))
+	} else {
+		b.WriteString(paint(colorBold, fmt.Sprintf("%d:%d: ", line+1, d.Start.Char+1)))
+	}
+
+	header := d.Severity.String()
+
+	if d.Code != "" {
+		header += "[" + d.Code + "]"
+	}
+
+	b.WriteString(paint(colorBold+color, header))
+	b.WriteString(paint(colorBold, ": "+d.Message))
+	b.WriteString("\n")
+
+	if line >= 0 && line < lines.LineCount() {
+		text := lines.LineText(line)
+		runes := []rune(text)
+		start := min(max(d.Start.Convert(text, opts.Encoding, EncodingRune).Char, 0), len(runes))
+		end := len(runes)
+
+		if d.End.Line == line {
+			end = min(max(d.End.Convert(text, opts.Encoding, EncodingRune).Char, start), len(runes))
+		}
+
+		var pad strings.Builder
+
+		for _, r := range runes[:start] {
+			if r == '\t' {
+				pad.WriteRune('\t')
+			} else {
+				pad.WriteRune(' ')
+			}
+		}
+
+		marker := "^" + strings.Repeat("~", max(end-start-1, 0))
+
+		b.WriteString(paint(colorBlue, fmt.Sprintf(" %s | ", lineNum)))
+		b.WriteString(text)
+		b.WriteString("\n")
+		b.WriteString(paint(colorBlue, fmt.Sprintf(" %s | ", gutter)))
+		b.WriteString(pad.String())
+		b.WriteString(paint(colorBold+color, marker))
+		b.WriteString("\n")
+	}
+
+	notes := []string{}
+
+	if d.Note != "" {
+		notes = append(notes, d.Note)
+	}
+
+	for _, related := range d.Related {
+		notes = append(notes, fmt.Sprintf("%d:%d: %s", related.Start.Line+1, related.Start.Char+1, related.Message))
+	}
+
+	for _, note := range notes {
+		b.WriteString(paint(colorBlue, fmt.Sprintf(" %s = ", gutter)))
+		b.WriteString(paint(colorBold, "note: "))
+		b.WriteString(note)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func severityColor(s Severity) string {
+	switch s {
+	case SeverityError:
+		return colorRed
+	case SeverityWarning:
+		return colorYellow
+	case SeverityInfo:
+		return colorBlue
+	case SeverityHint:
+		return colorCyan
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderDiagnostics(t *testing.T) {
+	g := NewWithT(t)
+
+	src := "Family\n\nІван + Марія = = Петро"
+	root := Parse(src)
+	root.Diagnostics[0].Note = "remove the second arrow"
+
+	var b strings.Builder
+
+	err := RenderDiagnostics(&b, src, root.Diagnostics, RenderOptions{Filename: "main.fml"})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(b.String()).To(Equal(strings.Join([]string{
+		`main.fml:3:16: error[duplicate-arrow]: relation already has an arrow, unexpected "="`,
+		` 3 | Іван + Марія = = Петро`,
+		`   |                ^`,
+		`   = note: remove the second arrow`,
+		``,
+	}, "\n")))
+
+	b.Reset()
+
+	err = RenderDiagnostics(&b, src, root.Diagnostics, RenderOptions{Color: true})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(b.String()).To(HavePrefix(colorBold + "3:16: "))
+	g.Expect(b.String()).To(ContainSubstring(colorRed))
+}
@@ -0,0 +1,387 @@
+package parser
+
+import (
+	"iter"
+	"slices"
+)
+
+type scopeKey struct {
+	family  *Family
+	surname string
+}
+
+type scope struct {
+	scopeKey
+	names map[string][]*Individual
+}
+
+type ResolveOptions struct {
+	UnifyUnknowns bool
+}
+
+type resolver struct {
+	model    *Model
+	opts     ResolveOptions
+	unknowns map[scopeKey]map[string]*Individual
+}
+
+func Resolve(root *Root) *Model {
+	return ResolveFamilies(root.Families)
+}
+
+func ResolveWithOptions(root *Root, opts ResolveOptions) *Model {
+	return ResolveFamiliesWithOptions(root.Families, opts)
+}
+
+func ResolveFamilies(families []*Family) *Model {
+	return ResolveFamiliesWithOptions(families, ResolveOptions{})
+}
+
+func ResolveFamiliesWithOptions(families []*Family, opts ResolveOptions) *Model {
+	r := &resolver{
+		opts:     opts,
+		unknowns: map[scopeKey]map[string]*Individual{},
+		model: &Model{
+			Families: families,
+			persons:  map[*Person]*Individual{},
+			families: map[string][]*Family{},
+			scopes:   map[scopeKey]*scope{},
+		},
+	}
+
+	r.indexFamilies()
+	r.resolveDefinitions()
+	r.resolveReferences()
+	r.detectCycles()
+
+	return r.model
+}
+
+func (r *resolver) indexFamilies() {
+	for _, family := range r.model.Families {
+		if family.Name != nil {
+			r.addFamily(family.Name.Text, family)
+		}
+	}
+
+	for _, family := range r.model.Families {
+		for _, alias := range family.Aliases {
+			r.addFamily(alias.Text, family)
+		}
+	}
+}
+
+func (r *resolver) addFamily(name string, family *Family) {
+	for _, item := range r.model.families[name] {
+		if item == family {
+			return
+		}
+	}
+
+	r.model.families[name] = append(r.model.families[name], family)
+}
+
+func (r *resolver) resolveDefinitions() {
+	for _, family := range r.model.Families {
+		for _, rel := range family.Relations {
+			if !rel.IsFamilyDef || rel.Targets == nil {
+				continue
+			}
+
+			for _, person := range rel.Targets.Persons {
+				if person.Name == nil {
+					continue
+				}
+
+				s := r.scopeOf(person, family)
+				ind := r.newIndividual(s, person.Name.Text)
+				ind.Definition = person
+				r.link(person, ind)
+				r.addAliases(s, person, ind)
+			}
+		}
+	}
+}
+
+func (r *resolver) resolveReferences() {
+	for _, family := range r.model.Families {
+		for _, rel := range family.Relations {
+			for person := range rel.PersonsIter() {
+				if r.model.persons[person] != nil {
+					continue
+				}
+
+				if person.Name == nil {
+					if person.Unknown != nil {
+						r.link(person, r.newUnknown(person, family))
+					}
+
+					continue
+				}
+
+				s := r.scopeOf(person, family)
+				list := s.lookup(person)
+
+				var ind *Individual
+
+				if len(list) == 0 {
+					ind = r.newIndividual(s, person.Name.Text)
+				} else {
+					ind = list[0]
+				}
+
+				if len(list) > 1 {
+					r.model.Ambiguities = append(r.model.Ambiguities, &Ambiguity{
+						Person:     person,
+						Candidates: list,
+					})
+				}
+
+				r.link(person, ind)
+				r.addAliases(s, person, ind)
+			}
+		}
+	}
+}
+
+func (r *resolver) detectCycles() {
+	parentOf := map[*Individual][]*Relation{}
+
+	for rel := range r.familyDefs() {
+		for _, source := range rel.Sources.Persons {
+			if parent := r.model.persons[source]; parent != nil {
+				parentOf[parent] = append(parentOf[parent], rel)
+			}
+		}
+	}
+
+	reported := map[*Person]bool{}
+
+	for _, item := range r.model.Ambiguities {
+		reported[item.Person] = true
+	}
+
+	for rel := range r.familyDefs() {
+		for _, source := range rel.Sources.Persons {
+			ind := r.model.persons[source]
+
+			if ind == nil || ind.Definition == nil || reported[source] {
+				continue
+			}
+
+			if !r.isDescendant(parentOf, rel, ind) {
+				continue
+			}
+
+			reported[source] = true
+			parentOf[ind] = slices.DeleteFunc(parentOf[ind], func(item *Relation) bool {
+				return item == rel
+			})
+
+			ind.Persons = slices.DeleteFunc(ind.Persons, func(item *Person) bool {
+				return item == source
+			})
+
+			s := r.scopeOf(source, rel.Family)
+			parent := r.newIndividual(s, source.Name.Text)
+			r.link(source, parent)
+			r.addAliases(s, source, parent)
+			parentOf[parent] = append(parentOf[parent], rel)
+
+			r.model.Ambiguities = append(r.model.Ambiguities, &Ambiguity{
+				Person:     source,
+				Candidates: []*Individual{ind},
+				IsCycle:    true,
+			})
+		}
+	}
+}
+
+func (r *resolver) familyDefs() iter.Seq[*Relation] {
+	return func(yield func(*Relation) bool) {
+		for _, family := range r.model.Families {
+			for _, rel := range family.Relations {
+				if !rel.IsFamilyDef || rel.Sources == nil || rel.Targets == nil {
+					continue
+				}
+
+				if !yield(rel) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *resolver) isDescendant(parentOf map[*Individual][]*Relation, rel *Relation, ind *Individual) bool {
+	visited := map[*Relation]bool{}
+	queue := []*Relation{rel}
+
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+
+		if visited[item] {
+			continue
+		}
+
+		visited[item] = true
+
+		for _, target := range item.Targets.Persons {
+			child := r.model.persons[target]
+
+			if child == nil {
+				continue
+			}
+
+			if child == ind {
+				return true
+			}
+
+			queue = append(queue, parentOf[child]...)
+		}
+	}
+
+	return false
+}
+
+func (r *resolver) scopeOf(person *Person, family *Family) *scope {
+	if person.Surname != nil {
+		return r.scope(r.model.surnameKey(person.Surname.Text))
+	}
+
+	return r.familyScope(family)
+}
+
+func (r *resolver) familyScope(family *Family) *scope {
+	return r.scope(r.model.familyKey(family))
+}
+
+func (m *Model) surnameKey(surname string) scopeKey {
+	if list := m.families[surname]; len(list) > 0 {
+		return m.familyKey(list[0])
+	}
+
+	return scopeKey{surname: surname}
+}
+
+func (m *Model) familyKey(family *Family) scopeKey {
+	if family.Name == nil {
+		return scopeKey{family: family}
+	}
+
+	return scopeKey{
+		family:  m.families[family.Name.Text][0],
+		surname: family.Name.Text,
+	}
+}
+
+func (r *resolver) scope(key scopeKey) *scope {
+	s, ok := r.model.scopes[key]
+
+	if !ok {
+		s = &scope{
+			scopeKey: key,
+			names:    map[string][]*Individual{},
+		}
+
+		r.model.scopes[key] = s
+	}
+
+	return s
+}
+
+func (r *resolver) newIndividual(s *scope, name string) *Individual {
+	ind := &Individual{
+		ID:      len(r.model.Individuals),
+		Name:    name,
+		Surname: s.surname,
+		Family:  s.family,
+	}
+
+	r.model.Individuals = append(r.model.Individuals, ind)
+	s.names[name] = append(s.names[name], ind)
+
+	return ind
+}
+
+func (r *resolver) addAliases(s *scope, person *Person, ind *Individual) {
+	for _, alias := range person.Aliases {
+		if slices.Contains(ind.Aliases, alias.Text) || alias.Text == ind.Name {
+			continue
+		}
+
+		ind.Aliases = append(ind.Aliases, alias.Text)
+		s.names[alias.Text] = append(s.names[alias.Text], ind)
+	}
+}
+
+func (s *scope) lookup(person *Person) []*Individual {
+	list := s.names[person.Name.Text]
+
+	if len(list) == 0 {
+		for _, alias := range person.Aliases {
+			if list = s.names[alias.Text]; len(list) > 0 {
+				break
+			}
+		}
+	}
+
+	if len(list) < 2 || len(person.Aliases) == 0 {
+		return list
+	}
+
+	var filtered []*Individual
+
+	for _, ind := range list {
+		for _, alias := range person.Aliases {
+			if ind.HasName(alias.Text) {
+				filtered = append(filtered, ind)
+				break
+			}
+		}
+	}
+
+	if len(filtered) == 0 {
+		return list
+	}
+
+	return filtered
+}
+
+func (r *resolver) newUnknown(person *Person, family *Family) *Individual {
+	s := r.familyScope(family)
+	name := person.Unknown.Text
+
+	if r.opts.UnifyUnknowns {
+		if ind := r.unknowns[s.scopeKey][name]; ind != nil {
+			return ind
+		}
+	}
+
+	ind := &Individual{
+		ID:        len(r.model.Individuals),
+		Name:      name,
+		Surname:   s.surname,
+		Family:    s.family,
+		Relation:  person.Relation,
+		IsUnknown: true,
+	}
+
+	r.model.Individuals = append(r.model.Individuals, ind)
+
+	if r.opts.UnifyUnknowns {
+		if r.unknowns[s.scopeKey] == nil {
+			r.unknowns[s.scopeKey] = map[string]*Individual{}
+		}
+
+		r.unknowns[s.scopeKey][name] = ind
+	}
+
+	return ind
+}
+
+func (r *resolver) link(person *Person, ind *Individual) {
+	r.model.persons[person] = ind
+	ind.Persons = append(ind.Persons, person)
+}
@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+const resolverSrc = `Petrenko (Petrenki)
+
+Ivan + Maria Shevchenko =
+Petro
+Olena
+
+Petro + Anna
+
+Olena + Taras Shevchenko
+
+Ostap Bondar + mother?
+
+Shevchenko
+
+Taras + Oksana =
+Maria
+
+Petro Petrenki + Ostap Bondar
+
+mother? + Taras
+`
+
+func TestResolve(t *testing.T) {
+	g := NewWithT(t)
+
+	root := Parse(resolverSrc)
+	model := Resolve(root)
+
+	petrenko := root.Families[0]
+	shevchenko := root.Families[1]
+
+	g.Expect(model.FamilyByName("Petrenki")).To(BeIdenticalTo(petrenko))
+	g.Expect(model.FamilyByName("Shevchenko")).To(BeIdenticalTo(shevchenko))
+
+	person := func(family, rel, side, index int) *Person {
+		r := root.Families[family].Relations[rel]
+		list := r.Sources
+
+		if side == 1 {
+			list = r.Targets
+		}
+
+		return list.Persons[index]
+	}
+
+	petro := model.Individual(person(0, 0, 1, 0))
+
+	g.Expect(petro).To(PointTo(MatchFields(IgnoreExtras, Fields{
+		"Name":       Equal("Petro"),
+		"Surname":    Equal("Petrenko"),
+		"Family":     BeIdenticalTo(petrenko),
+		"Definition": BeIdenticalTo(person(0, 0, 1, 0)),
+		"Persons":    HaveLen(3),
+	})))
+
+	g.Expect(model.Individual(person(0, 1, 0, 0))).To(BeIdenticalTo(petro))
+	g.Expect(model.Individual(person(1, 1, 0, 0))).To(BeIdenticalTo(petro))
+
+	maria := model.Individual(person(0, 0, 0, 1))
+
+	g.Expect(maria.Family).To(BeIdenticalTo(shevchenko))
+	g.Expect(maria.Definition).To(BeIdenticalTo(person(1, 0, 1, 0)))
+
+	taras := model.Individual(person(0, 2, 0, 1))
+
+	g.Expect(taras.FullName()).To(Equal("Taras Shevchenko"))
+	g.Expect(taras.Definition).To(BeNil())
+	g.Expect(model.Individual(person(1, 0, 0, 0))).To(BeIdenticalTo(taras))
+	g.Expect(model.Individual(person(1, 2, 0, 1))).To(BeIdenticalTo(taras))
+
+	ostap := model.Individual(person(0, 3, 0, 0))
+
+	g.Expect(ostap.Family).To(BeNil())
+	g.Expect(ostap.Surname).To(Equal("Bondar"))
+	g.Expect(model.Individual(person(1, 1, 0, 1))).To(BeIdenticalTo(ostap))
+
+	mother1 := model.Individual(person(0, 3, 0, 1))
+	mother2 := model.Individual(person(1, 2, 0, 0))
+
+	g.Expect(mother1.IsUnknown).To(BeTrue())
+	g.Expect(mother2.IsUnknown).To(BeTrue())
+	g.Expect(mother1).NotTo(BeIdenticalTo(mother2))
+
+	for i, ind := range model.Individuals {
+		g.Expect(ind.ID).To(Equal(i))
+	}
+}
+
+func TestResolveAliases(t *testing.T) {
+	g := NewWithT(t)
+
+	root := Parse(`Petrenko (Petrenki)
+
+Ivan + Maria =
+Petro (Petrus)
+Olena (Lena, Olenka)
+
+Petrus + Anna
+
+Shevchenko
+
+Lena Petrenki + Taras (Tarasyk)
+
+Tarasyk + Oksana
+`)
+	model := Resolve(root)
+
+	petrenko := root.Families[0]
+	shevchenko := root.Families[1]
+
+	petro := model.Individual(petrenko.Relations[0].Targets.Persons[0])
+	olena := model.Individual(petrenko.Relations[0].Targets.Persons[1])
+	taras := model.Individual(shevchenko.Relations[0].Sources.Persons[1])
+
+	g.Expect(petro.Aliases).To(Equal([]string{"Petrus"}))
+	g.Expect(model.Individual(petrenko.Relations[1].Sources.Persons[0])).To(BeIdenticalTo(petro))
+	g.Expect(model.Individual(shevchenko.Relations[0].Sources.Persons[0])).To(BeIdenticalTo(olena))
+	g.Expect(model.Individual(shevchenko.Relations[1].Sources.Persons[0])).To(BeIdenticalTo(taras))
+
+	g.Expect(model.Lookup("Olenka", "Petrenki")).To(Equal([]*Individual{olena}))
+	g.Expect(model.Lookup("Olena", "Petrenko")).To(Equal([]*Individual{olena}))
+	g.Expect(model.Lookup("Petrus", "")).To(Equal([]*Individual{petro}))
+	g.Expect(model.Lookup("Tarasyk", "Shevchenko")).To(Equal([]*Individual{taras}))
+	g.Expect(model.Lookup("Olena", "Shevchenko")).To(BeEmpty())
+}
+
+func TestResolveUnknowns(t *testing.T) {
+	g := NewWithT(t)
+
+	root := Parse(`Family
+
+mother? + Ivan =
+Petro
+
+mother? + Ivan =
+Olena
+
+father? + unknown?
+
+Other
+
+mother? + Taras
+`)
+
+	relations := root.Families[0].Relations
+	other := root.Families[1]
+
+	model := Resolve(root)
+	unknowns := model.Unknowns()
+
+	g.Expect(unknowns).To(HaveLen(5))
+	g.Expect(unknowns[0].Relation).To(BeIdenticalTo(relations[0]))
+	g.Expect(unknowns[1].Relation).To(BeIdenticalTo(relations[1]))
+	g.Expect(unknowns[0]).NotTo(BeIdenticalTo(unknowns[1]))
+	g.Expect(model.Individual(relations[0].Sources.Persons[1])).To(BeIdenticalTo(model.Individual(relations[1].Sources.Persons[1])))
+
+	model = ResolveWithOptions(root, ResolveOptions{UnifyUnknowns: true})
+	unknowns = model.Unknowns()
+
+	g.Expect(unknowns).To(HaveLen(4))
+	g.Expect(model.Individual(relations[0].Sources.Persons[0])).To(BeIdenticalTo(model.Individual(relations[1].Sources.Persons[0])))
+	g.Expect(unknowns[0].Persons).To(HaveLen(2))
+	g.Expect(unknowns[0].Relation).To(BeIdenticalTo(relations[0]))
+	g.Expect(model.Individual(other.Relations[0].Sources.Persons[0])).NotTo(BeIdenticalTo(unknowns[0]))
+}
+
+func TestResolveAmbiguities(t *testing.T) {
+	g := NewWithT(t)
+
+	root := Parse(`Family
+
+A + B =
+Ivan (Vanya)
+
+C + D =
+Ivan
+
+Ivan + E
+
+Vanya + F
+
+Ivan (Vanya) + G
+`)
+	model := Resolve(root)
+	family := root.Families[0]
+
+	first := model.Individual(family.Relations[0].Targets.Persons[0])
+	second := model.Individual(family.Relations[1].Targets.Persons[0])
+
+	g.Expect(first).NotTo(BeIdenticalTo(second))
+	g.Expect(model.Individual(family.Relations[3].Sources.Persons[0])).To(BeIdenticalTo(first))
+	g.Expect(model.Individual(family.Relations[4].Sources.Persons[0])).To(BeIdenticalTo(first))
+
+	g.Expect(model.Ambiguities).To(HaveLen(1))
+	g.Expect(model.Ambiguities[0]).To(PointTo(MatchAllFields(Fields{
+		"Person":     BeIdenticalTo(family.Relations[2].Sources.Persons[0]),
+		"Candidates": Equal([]*Individual{first, second}),
+		"IsCycle":    BeFalse(),
+	})))
+
+	root = Parse(`Family
+
+Ivan + Maria =
+Petro
+
+Petro + Anna =
+Ivan
+`)
+	model = Resolve(root)
+	family = root.Families[0]
+
+	grandson := model.Individual(family.Relations[1].Targets.Persons[0])
+	grandfather := model.Individual(family.Relations[0].Sources.Persons[0])
+
+	g.Expect(grandfather).NotTo(BeNil())
+	g.Expect(grandfather).NotTo(BeIdenticalTo(grandson))
+	g.Expect(grandfather.Persons).To(Equal([]*Person{family.Relations[0].Sources.Persons[0]}))
+	g.Expect(grandson.Persons).To(Equal([]*Person{family.Relations[1].Targets.Persons[0]}))
+	g.Expect(model.Ambiguities).To(HaveLen(1))
+	g.Expect(model.Ambiguities[0]).To(PointTo(MatchAllFields(Fields{
+		"Person":     BeIdenticalTo(family.Relations[0].Sources.Persons[0]),
+		"Candidates": Equal([]*Individual{grandson}),
+		"IsCycle":    BeTrue(),
+	})))
+
+	model = Resolve(Parse("Petrenko\n\nIvan + Maria = Petro\n\nPetro + Olena = Ivan\n"))
+	graph := NewGraph(model)
+	petro := model.Individual(model.Families[0].Relations[0].Targets.Persons[0])
+
+	g.Expect(model.Ambiguities).To(HaveLen(1))
+	g.Expect(model.Ambiguities[0].IsCycle).To(BeTrue())
+	g.Expect(graph.Parents(petro)).NotTo(ContainElement(graph.Children(petro)[0]))
+}
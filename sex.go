@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+type Confidence int
+
+const (
+	ConfidenceNone Confidence = iota
+	ConfidenceLow
+	ConfidenceMedium
+	ConfidenceHigh
+)
+
+type SexOptions struct {
+	Words map[string]Sex
+	Names map[string]Sex
+}
+
+var DefaultSexWords = map[string]Sex{
+	"husband":       SexMale,
+	"father":        SexMale,
+	"dad":           SexMale,
+	"son":           SexMale,
+	"brother":       SexMale,
+	"grandfather":   SexMale,
+	"grandson":      SexMale,
+	"uncle":         SexMale,
+	"nephew":        SexMale,
+	"stepfather":    SexMale,
+	"stepson":       SexMale,
+	"groom":         SexMale,
+	"man":           SexMale,
+	"wife":          SexFemale,
+	"mother":        SexFemale,
+	"mom":           SexFemale,
+	"daughter":      SexFemale,
+	"sister":        SexFemale,
+	"grandmother":   SexFemale,
+	"granddaughter": SexFemale,
+	"aunt":          SexFemale,
+	"niece":         SexFemale,
+	"stepmother":    SexFemale,
+	"stepdaughter":  SexFemale,
+	"bride":         SexFemale,
+	"woman":         SexFemale,
+	"чоловік":       SexMale,
+	"батько":        SexMale,
+	"тато":          SexMale,
+	"син":           SexMale,
+	"брат":          SexMale,
+	"дід":           SexMale,
+	"дідусь":        SexMale,
+	"онук":          SexMale,
+	"дядько":        SexMale,
+	"племінник":     SexMale,
+	"вітчим":        SexMale,
+	"пасинок":       SexMale,
+	"наречений":     SexMale,
+	"дружина":       SexFemale,
+	"жінка":         SexFemale,
+	"мати":          SexFemale,
+	"мама":          SexFemale,
+	"донька":        SexFemale,
+	"дочка":         SexFemale,
+	"сестра":        SexFemale,
+	"баба":          SexFemale,
+	"бабуся":        SexFemale,
+	"онука":         SexFemale,
+	"тітка":         SexFemale,
+	"племінниця":    SexFemale,
+	"мачуха":        SexFemale,
+	"падчерка":      SexFemale,
+	"наречена":      SexFemale,
+}
+
+func InferSex(g *Graph, opts SexOptions) {
+	words := opts.Words
+
+	if words == nil {
+		words = DefaultSexWords
+	}
+
+	set := func(ind *Individual, sex Sex, confidence Confidence) bool {
+		if sex == SexUnknown || ind.SexConfidence >= confidence {
+			return false
+		}
+
+		ind.Sex = sex
+		ind.SexConfidence = confidence
+
+		return true
+	}
+
+	for _, ind := range g.Model.Individuals {
+		if ind.IsUnknown {
+			set(ind, sexOfWords(ind.Name, words), ConfidenceHigh)
+			continue
+		}
+
+		for _, name := range append([]string{ind.Name}, ind.Aliases...) {
+			if sex, ok := opts.Names[name]; ok {
+				set(ind, sex, ConfidenceMedium)
+				break
+			}
+		}
+	}
+
+	for _, family := range g.Model.Families {
+		for _, rel := range family.Relations {
+			if rel.IsFamilyDef || rel.Label == nil {
+				continue
+			}
+
+			for _, ind := range g.individuals(rel.Targets) {
+				set(ind, sexOfWords(rel.Label.Text, words), ConfidenceHigh)
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, edge := range g.Edges {
+			if edge.Type != EdgeSpouse {
+				continue
+			}
+
+			for _, pair := range [][2]*Individual{{edge.From, edge.To}, {edge.To, edge.From}} {
+				known, other := pair[0], pair[1]
+
+				if known.SexConfidence < ConfidenceMedium || other.SexConfidence > ConfidenceNone {
+					continue
+				}
+
+				if set(other, oppositeSex(known.Sex), ConfidenceLow) {
+					changed = true
+				}
+			}
+		}
+	}
+}
+
+func sexOfWords(text string, words map[string]Sex) Sex {
+	result := SexUnknown
+
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\'' && r != '-'
+	})
+
+	for _, word := range fields {
+		sex, ok := words[word]
+
+		if !ok {
+			continue
+		}
+
+		if result != SexUnknown && result != sex {
+			return SexUnknown
+		}
+
+		result = sex
+	}
+
+	return result
+}
+
+func oppositeSex(sex Sex) Sex {
+	switch sex {
+	case SexMale:
+		return SexFemale
+
+	case SexFemale:
+		return SexMale
+
+	default:
+		return SexUnknown
+	}
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestInferSex(t *testing.T) {
+	g := NewWithT(t)
+
+	model := Resolve(Parse(`Family
+
+Ivan + Maria =
+Petro
+Olena
+
+Petro + Anna
+
+Olena -> чоловік Taras
+
+mother? + Bohdan
+
+Ostap - stepmother Oksana
+
+Oksana + Mykola
+
+Stepan <- mother Halyna
+`))
+	graph := NewGraph(model)
+
+	InferSex(graph, SexOptions{
+		Names: map[string]Sex{
+			"Ivan": SexMale,
+		},
+	})
+
+	type result struct {
+		Sex        Sex
+		Confidence Confidence
+	}
+
+	results := map[string]result{}
+
+	for _, ind := range model.Individuals {
+		results[ind.Name] = result{ind.Sex, ind.SexConfidence}
+	}
+
+	g.Expect(results).To(Equal(map[string]result{
+		"Ivan":    {SexMale, ConfidenceMedium},
+		"Maria":   {SexFemale, ConfidenceLow},
+		"Petro":   {SexUnknown, ConfidenceNone},
+		"Olena":   {SexUnknown, ConfidenceNone},
+		"Anna":    {SexUnknown, ConfidenceNone},
+		"Taras":   {SexMale, ConfidenceHigh},
+		"mother?": {SexFemale, ConfidenceHigh},
+		"Bohdan":  {SexMale, ConfidenceLow},
+		"Ostap":   {SexUnknown, ConfidenceNone},
+		"Oksana":  {SexFemale, ConfidenceHigh},
+		"Mykola":  {SexMale, ConfidenceLow},
+		"Stepan":  {SexUnknown, ConfidenceNone},
+		"Halyna":  {SexFemale, ConfidenceHigh},
+	}))
+}
@@ -64,16 +64,67 @@ func _() {
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
 	_ = x[ErrUnexpected-1]
+	_ = x[ErrUnclosedBracket-2]
+	_ = x[ErrStrayBracket-4]
+	_ = x[ErrDuplicateArrow-8]
+	_ = x[ErrFamilySurname-16]
+	_ = x[ErrDuplicateName-32]
+	_ = x[ErrDuplicateSurname-64]
+	_ = x[ErrOrphanChild-128]
+	_ = x[ErrStrayPunctuation-256]
+	_ = x[ErrDisabledAliases-512]
+	_ = x[ErrDisabledUnknown-1024]
+	_ = x[ErrDisabledNumbers-2048]
+	_ = x[ErrDisabledLabels-4096]
 }
 
-const _ErrType_name = "ErrUnexpected"
+const (
+	_ErrType_name_0  = "ErrUnexpectedErrUnclosedBracket"
+	_ErrType_name_1  = "ErrStrayBracket"
+	_ErrType_name_2  = "ErrDuplicateArrow"
+	_ErrType_name_3  = "ErrFamilySurname"
+	_ErrType_name_4  = "ErrDuplicateName"
+	_ErrType_name_5  = "ErrDuplicateSurname"
+	_ErrType_name_6  = "ErrOrphanChild"
+	_ErrType_name_7  = "ErrStrayPunctuation"
+	_ErrType_name_8  = "ErrDisabledAliases"
+	_ErrType_name_9  = "ErrDisabledUnknown"
+	_ErrType_name_10 = "ErrDisabledNumbers"
+	_ErrType_name_11 = "ErrDisabledLabels"
+)
 
-var _ErrType_index = [...]uint8{0, 13}
+var (
+	_ErrType_index_0 = [...]uint8{0, 13, 31}
+)
 
 func (i ErrType) String() string {
-	i -= 1
-	if i < 0 || i >= ErrType(len(_ErrType_index)-1) {
-		return "ErrType(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	switch {
+	case 1 <= i && i <= 2:
+		i -= 1
+		return _ErrType_name_0[_ErrType_index_0[i]:_ErrType_index_0[i+1]]
+	case i == 4:
+		return _ErrType_name_1
+	case i == 8:
+		return _ErrType_name_2
+	case i == 16:
+		return _ErrType_name_3
+	case i == 32:
+		return _ErrType_name_4
+	case i == 64:
+		return _ErrType_name_5
+	case i == 128:
+		return _ErrType_name_6
+	case i == 256:
+		return _ErrType_name_7
+	case i == 512:
+		return _ErrType_name_8
+	case i == 1024:
+		return _ErrType_name_9
+	case i == 2048:
+		return _ErrType_name_10
+	case i == 4096:
+		return _ErrType_name_11
+	default:
+		return "ErrType(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return _ErrType_name[_ErrType_index[i]:_ErrType_index[i+1]]
 }
@@ -0,0 +1,131 @@
+package parser
+
+import "iter"
+
+type TraverseOrder int
+
+const (
+	OrderBFS TraverseOrder = iota
+	OrderDFS
+)
+
+type TraverseOptions struct {
+	Order    TraverseOrder
+	MaxDepth int
+}
+
+func (g *Graph) Ancestors(ind *Individual, opts TraverseOptions) iter.Seq2[*Individual, int] {
+	return traverse(ind, g.Parents, opts)
+}
+
+func (g *Graph) Descendants(ind *Individual, opts TraverseOptions) iter.Seq2[*Individual, int] {
+	return traverse(ind, g.Children, opts)
+}
+
+func traverse(start *Individual, next func(*Individual) []*Individual, opts TraverseOptions) iter.Seq2[*Individual, int] {
+	return func(yield func(*Individual, int) bool) {
+		visited := map[*Individual]bool{start: true}
+
+		canGo := func(generation int) bool {
+			return opts.MaxDepth <= 0 || generation < opts.MaxDepth
+		}
+
+		if opts.Order == OrderDFS {
+			best := map[*Individual]int{start: 0}
+			level := []*Individual{start}
+			depth := 0
+
+			generationOf := func(ind *Individual) int {
+				for {
+					if generation, ok := best[ind]; ok || len(level) == 0 {
+						return generation
+					}
+
+					var items []*Individual
+
+					for _, cur := range level {
+						for _, item := range next(cur) {
+							if _, ok := best[item]; !ok {
+								best[item] = depth + 1
+								items = append(items, item)
+							}
+						}
+					}
+
+					level = items
+					depth++
+				}
+			}
+
+			type frame struct {
+				items []*Individual
+				index int
+			}
+
+			var stack []*frame
+
+			if canGo(0) {
+				stack = append(stack, &frame{items: next(start)})
+			}
+
+			for len(stack) > 0 {
+				top := stack[len(stack)-1]
+
+				if top.index >= len(top.items) {
+					stack = stack[:len(stack)-1]
+					continue
+				}
+
+				item := top.items[top.index]
+				top.index++
+
+				if visited[item] {
+					continue
+				}
+
+				visited[item] = true
+				generation := generationOf(item)
+
+				if !yield(item, generation) {
+					return
+				}
+
+				if canGo(generation) {
+					stack = append(stack, &frame{items: next(item)})
+				}
+			}
+
+			return
+		}
+
+		type entry struct {
+			ind        *Individual
+			generation int
+		}
+
+		queue := []entry{{start, 0}}
+
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+
+			if !canGo(cur.generation) {
+				continue
+			}
+
+			for _, item := range next(cur.ind) {
+				if visited[item] {
+					continue
+				}
+
+				visited[item] = true
+
+				if !yield(item, cur.generation+1) {
+					return
+				}
+
+				queue = append(queue, entry{item, cur.generation + 1})
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTraverse(t *testing.T) {
+	g := NewWithT(t)
+
+	src := `Family
+
+A + B =
+C
+D
+
+C + E =
+F
+
+D + G =
+H
+
+F + I =
+J
+`
+
+	model := Resolve(Parse(src))
+	graph := NewGraph(model)
+	individuals := map[string]*Individual{}
+
+	for _, ind := range model.Individuals {
+		individuals[ind.Name] = ind
+	}
+
+	collect := func(seq iter.Seq2[*Individual, int]) (list []string) {
+		for ind, generation := range seq {
+			list = append(list, fmt.Sprintf("%s%d", ind.Name, generation))
+		}
+
+		return
+	}
+
+	g.Expect(collect(graph.Descendants(individuals["A"], TraverseOptions{}))).To(Equal([]string{"C1", "D1", "F2", "H2", "J3"}))
+	g.Expect(collect(graph.Descendants(individuals["A"], TraverseOptions{Order: OrderDFS}))).To(Equal([]string{"C1", "F2", "J3", "D1", "H2"}))
+	g.Expect(collect(graph.Descendants(individuals["A"], TraverseOptions{MaxDepth: 2}))).To(Equal([]string{"C1", "D1", "F2", "H2"}))
+	g.Expect(collect(graph.Descendants(individuals["A"], TraverseOptions{Order: OrderDFS, MaxDepth: 1}))).To(Equal([]string{"C1", "D1"}))
+	g.Expect(collect(graph.Ancestors(individuals["J"], TraverseOptions{}))).To(Equal([]string{"F1", "I1", "C2", "E2", "A3", "B3"}))
+
+	var first []string
+
+	for ind := range graph.Ancestors(individuals["J"], TraverseOptions{Order: OrderDFS}) {
+		first = append(first, ind.Name)
+
+		if len(first) == 2 {
+			break
+		}
+	}
+
+	g.Expect(first).To(Equal([]string{"F", "C"}))
+
+	model = Resolve(Parse("Family\n\nA + B =\nC\n\nC + E =\nF\n\nA + F =\nG\n\nG + H =\nK\n"))
+	graph = NewGraph(model)
+
+	for _, ind := range model.Individuals {
+		individuals[ind.Name] = ind
+	}
+
+	g.Expect(collect(graph.Descendants(individuals["A"], TraverseOptions{Order: OrderDFS}))).To(Equal([]string{"C1", "F2", "G1", "K2"}))
+	g.Expect(collect(graph.Descendants(individuals["A"], TraverseOptions{Order: OrderDFS, MaxDepth: 3}))).To(Equal([]string{"C1", "F2", "G1", "K2"}))
+	g.Expect(collect(graph.Descendants(individuals["A"], TraverseOptions{Order: OrderDFS, MaxDepth: 2}))).To(Equal([]string{"C1", "F2", "G1", "K2"}))
+
+	chain := make([]*Individual, 100)
+
+	for i := range chain {
+		chain[i] = &Individual{ID: i}
+	}
+
+	calls := 0
+	next := func(ind *Individual) []*Individual {
+		calls++
+
+		if ind.ID+1 < len(chain) {
+			return chain[ind.ID+1 : ind.ID+2]
+		}
+
+		return nil
+	}
+
+	for range traverse(chain[0], next, TraverseOptions{Order: OrderDFS}) {
+		break
+	}
+
+	g.Expect(calls).To(BeNumerically("<=", 2))
+}
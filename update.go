@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"context"
+	"slices"
+)
+
+type Edit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// Update reparses src, the text after edit was applied. It takes ownership of
+// root and tokens: families and tokens after the edit are reused with their
+// positions shifted in place, so neither the old root nor the old token slice
+// may be used, even concurrently, after the call.
+func Update(src string, root *Root, tokens []*Token, edit Edit) (*Root, []*Token) {
+	return UpdateWithOptions(src, root, tokens, edit, ParseOptions{})
+}
+
+// UpdateWithOptions is Update with parse options; it has the same ownership rules.
+func UpdateWithOptions(src string, root *Root, tokens []*Token, edit Edit, opts ParseOptions) (*Root, []*Token) {
+	starts, ok := familyStarts(root.Families, tokens)
+	list, change := LexerUpdateWithOptions(src, tokens, edit, opts.lexer())
+
+	if ok {
+		if newRoot, ok := reparse(root, starts, list, change, opts); ok {
+			return newRoot, list
+		}
+	}
+
+	resetErrors(list)
+
+	return ParseTokensWithOptions(list, opts), list
+}
+
+func reparse(root *Root, starts []int, tokens []*Token, change LexerChange, opts ParseOptions) (*Root, bool) {
+	count := len(starts)
+	shift := change.NewEnd - change.OldEnd
+	head := 0
+
+	for head+1 < count && starts[head+1] < change.Start {
+		head++
+	}
+
+	from := 0
+
+	if head > 0 {
+		from = starts[head]
+	}
+
+	tail := count
+
+	for i := head; i < count; i++ {
+		if starts[i] >= change.OldEnd {
+			tail = i
+			break
+		}
+	}
+
+	to := len(tokens)
+
+	if tail < count {
+		to = starts[tail] + shift
+	}
+
+	resetErrors(tokens[from:to])
+
+	middle, _ := visitRoot(context.Background(), NewCursor(tokens[from:to]), opts)
+
+	if tail < count {
+		if n := len(middle.Families); n > 0 && middle.Families[n-1].Name == nil {
+			return nil, false
+		}
+
+		if root.Families[tail].Name != tokens[to] && (head > 0 || len(middle.Families) > 0) {
+			return nil, false
+		}
+
+		lineDelta := tokens[to].Line - root.Families[tail].Start.Line
+
+		for _, family := range root.Families[tail:] {
+			shiftFamily(family, lineDelta)
+		}
+	}
+
+	result := &Root{}
+
+	if head > 0 {
+		result.Comments = root.Comments
+	} else {
+		result.Comments = middle.Comments
+	}
+
+	result.Families = slices.Concat(root.Families[:head], middle.Families, root.Families[tail:])
+
+	c := NewCursor(tokens)
+
+	if start := c.PickNext(); start != nil {
+		result.Start = toPos(start)
+		c.Index = c.Count
+		result.End = toEndPos(c.PickPrev())
+	}
+
+	return finishRoot(result, tokens, opts), true
+}
+
+func resetErrors(tokens []*Token) {
+	for _, token := range tokens {
+		token.ErrType = 0
+	}
+}
+
+func familyStarts(families []*Family, tokens []*Token) ([]int, bool) {
+	starts := make([]int, 0, len(families))
+	index := 0
+
+	for _, family := range families {
+		for ; index < len(tokens); index++ {
+			pos := toPos(tokens[index])
+
+			if pos.Compare(family.Start) != PosLt {
+				break
+			}
+		}
+
+		if index >= len(tokens) || toPos(tokens[index]) != family.Start {
+			return nil, false
+		}
+
+		starts = append(starts, index)
+		index++
+	}
+
+	return starts, true
+}
+
+func shiftFamily(family *Family, lineDelta int) {
+	family.shift(lineDelta)
+
+	for _, rel := range family.Relations {
+		rel.shift(lineDelta)
+
+		for _, list := range []*RelList{rel.Sources, rel.Targets} {
+			if list == nil || len(list.Persons) == 0 {
+				continue
+			}
+
+			list.shift(lineDelta)
+
+			for _, person := range list.Persons {
+				person.shift(lineDelta)
+			}
+		}
+	}
+}
+
+func (loc *Loc) shift(lineDelta int) {
+	loc.Start.Line += lineDelta
+	loc.End.Line += lineDelta
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	. "github.com/onsi/gomega"
+)
+
+const updateSrc = `// root comment
+Ivan + Maria =
+Petro
+
+Petrenko (Petrenki)
+
+Petro + Anna =
+1. Olena (Lena)
+2. Taras Shevchenko
+
+Olena + mother? -> friend // note
+
+Shevchenko
+
+Taras + Oksana = Andriy
+
+Bondar
+
+Ostap + Ivanna Petrenki
+`
+
+func TestUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	tokens := Lexer(updateSrc)
+	root := ParseTokens(tokens)
+	families := slices.Clone(root.Families)
+
+	offset := strings.Index(updateSrc, "Andriy")
+	src := updateSrc[:offset] + "Andriy\nMykola" + updateSrc[offset+len("Andriy"):]
+	root, tokens = Update(src, root, tokens, Edit{Start: offset, End: offset + len("Andriy"), Text: "Andriy\nMykola"})
+
+	g.Expect(root.Families).To(HaveLen(3))
+	g.Expect(root.Families[0]).To(BeIdenticalTo(families[0]))
+	g.Expect(root.Families[1]).NotTo(BeIdenticalTo(families[1]))
+	g.Expect(root.Families[2]).To(BeIdenticalTo(families[2]))
+	g.Expect(root.Families[2].Start).To(Equal(Position{Line: 17, Char: 0}))
+	g.Expect(root.Families[1].Relations[0].Targets.Persons).To(HaveLen(2))
+
+	expect := Lexer(src)
+
+	g.Expect(tokens).To(Equal(expect))
+	g.Expect(root).To(Equal(ParseTokens(expect)))
+}
+
+func TestUpdateMatchesParse(t *testing.T) {
+	inserts := []string{"", "x", "\n", "\n\n", "Family\n\n", "(", "Ivan ", "// c\n"}
+
+	for _, src := range []string{updateSrc, "Ivan + Maria =\nPetro\n\nPetro + Anna\n\nOlena\n", "Petrenko\n\nIvan + Maria =\nPetro\n\nShevchenko\n\nIvan - , \n"} {
+		for start := 0; start <= len(src); {
+			for _, length := range []int{0, 1, 12} {
+				end := min(start+length, len(src))
+
+				for end < len(src) && !utf8.RuneStart(src[end]) {
+					end++
+				}
+
+				for _, text := range inserts {
+					edit := Edit{Start: start, End: end, Text: text}
+					newSrc := src[:start] + text + src[end:]
+					tokens := Lexer(src)
+					root, list := Update(newSrc, ParseTokens(tokens), tokens, edit)
+					expectTokens := Lexer(newSrc)
+					expectRoot := ParseTokens(expectTokens)
+
+					if !reflect.DeepEqual(list, expectTokens) || !reflect.DeepEqual(root, expectRoot) {
+						t.Fatalf("update %+v differs from parse of:\n%s", edit, newSrc)
+					}
+				}
+			}
+
+			_, size := utf8.DecodeRuneInString(src[start:])
+			start += max(size, 1)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWorkspace(t *testing.T) {
+	g := NewWithT(t)
+
+	w := NewWorkspace(ParseOptions{})
+
+	petrenko := w.Update("file:///petrenko.fml", "Petrenko (Petrenki)\n\nIvan + Maria Shevchenko =\nPetro")
+	shevchenko := w.Update("file:///shevchenko.fml", "Shevchenko\n\nTaras + Oksana =\nMaria\n\nPetro Petrenki + Maria")
+
+	g.Expect(w.Families("Petrenki")).To(Equal([]FamilyRef{{"file:///petrenko.fml", petrenko.Root.Families[0]}}))
+	g.Expect(w.FamilyURI(shevchenko.Root.Families[0])).To(Equal("file:///shevchenko.fml"))
+
+	model := w.Model()
+
+	maria := model.Individual(shevchenko.Root.Families[0].Relations[0].Targets.Persons[0])
+	petro := model.Individual(petrenko.Root.Families[0].Relations[0].Targets.Persons[0])
+
+	g.Expect(model.Individual(petrenko.Root.Families[0].Relations[0].Sources.Persons[1])).To(BeIdenticalTo(maria))
+	g.Expect(model.Individual(shevchenko.Root.Families[0].Relations[1].Sources.Persons[0])).To(BeIdenticalTo(petro))
+	g.Expect(w.Model()).To(BeIdenticalTo(model))
+
+	renamed := w.Update("file:///petrenko.fml", "Petrenko\n\nIvan + Maria Shevchenko =\nPetro")
+
+	g.Expect(w.Document("file:///shevchenko.fml")).To(BeIdenticalTo(shevchenko))
+	g.Expect(w.Families("Petrenki")).To(BeEmpty())
+	g.Expect(w.Families("Petrenko")).To(Equal([]FamilyRef{{"file:///petrenko.fml", renamed.Root.Families[0]}}))
+
+	model = w.Model()
+
+	g.Expect(model.Individual(shevchenko.Root.Families[0].Relations[1].Sources.Persons[0]).Family).To(BeNil())
+
+	w.Remove("file:///petrenko.fml")
+
+	g.Expect(w.Documents()).To(Equal([]*Document{shevchenko}))
+	g.Expect(w.Families("Petrenko")).To(BeEmpty())
+	g.Expect(w.Model().Individuals).To(HaveLen(4))
+}